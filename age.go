@@ -0,0 +1,114 @@
+package deckstrings
+
+import "time"
+
+// SetRelease is a card set and the date it was released.
+type SetRelease struct {
+	// Set is the HearthstoneJSON set code, e.g. "TITANS".
+	Set string
+
+	// Name is the display name of the set, e.g. "TITANS".
+	Name string
+
+	Released time.Time
+}
+
+// Year returns the calendar year in which the set was released.
+func (r SetRelease) Year() int {
+	return r.Released.Year()
+}
+
+// SetReleases maps HearthstoneJSON set codes to their releases.
+type SetReleases map[string]SetRelease
+
+func setRelease(set, name string, year int, month time.Month, day int) SetRelease {
+	return SetRelease{Set: set, Name: name, Released: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DefaultSetReleases lists the release dates of Hearthstone's expansions and
+// adventures. The Core set is replaced every year under the same set code, so
+// it is not included, nor are sets for other game modes.
+var DefaultSetReleases = SetReleases{
+	"LEGACY":                  setRelease("LEGACY", "Legacy", 2014, time.March, 11),
+	"EXPERT1":                 setRelease("EXPERT1", "Classic", 2014, time.March, 11),
+	"NAXX":                    setRelease("NAXX", "Curse of Naxxramas", 2014, time.July, 22),
+	"GVG":                     setRelease("GVG", "Goblins vs Gnomes", 2014, time.December, 8),
+	"BRM":                     setRelease("BRM", "Blackrock Mountain", 2015, time.April, 2),
+	"TGT":                     setRelease("TGT", "The Grand Tournament", 2015, time.August, 24),
+	"LOE":                     setRelease("LOE", "The League of Explorers", 2015, time.November, 12),
+	"OG":                      setRelease("OG", "Whispers of the Old Gods", 2016, time.April, 26),
+	"KARA":                    setRelease("KARA", "One Night in Karazhan", 2016, time.August, 11),
+	"GANGS":                   setRelease("GANGS", "Mean Streets of Gadgetzan", 2016, time.December, 1),
+	"UNGORO":                  setRelease("UNGORO", "Journey to Un'Goro", 2017, time.April, 6),
+	"ICECROWN":                setRelease("ICECROWN", "Knights of the Frozen Throne", 2017, time.August, 10),
+	"LOOTAPALOOZA":            setRelease("LOOTAPALOOZA", "Kobolds & Catacombs", 2017, time.December, 7),
+	"GILNEAS":                 setRelease("GILNEAS", "The Witchwood", 2018, time.April, 12),
+	"BOOMSDAY":                setRelease("BOOMSDAY", "The Boomsday Project", 2018, time.August, 7),
+	"TROLL":                   setRelease("TROLL", "Rastakhan's Rumble", 2018, time.December, 4),
+	"DALARAN":                 setRelease("DALARAN", "Rise of Shadows", 2019, time.April, 9),
+	"ULDUM":                   setRelease("ULDUM", "Saviors of Uldum", 2019, time.August, 6),
+	"DRAGONS":                 setRelease("DRAGONS", "Descent of Dragons", 2019, time.December, 10),
+	"YEAR_OF_THE_DRAGON":      setRelease("YEAR_OF_THE_DRAGON", "Galakrond's Awakening", 2020, time.January, 21),
+	"DEMON_HUNTER_INITIATE":   setRelease("DEMON_HUNTER_INITIATE", "Demon Hunter Initiate", 2020, time.April, 2),
+	"BLACK_TEMPLE":            setRelease("BLACK_TEMPLE", "Ashes of Outland", 2020, time.April, 7),
+	"SCHOLOMANCE":             setRelease("SCHOLOMANCE", "Scholomance Academy", 2020, time.August, 6),
+	"DARKMOON_FAIRE":          setRelease("DARKMOON_FAIRE", "Madness at the Darkmoon Faire", 2020, time.November, 17),
+	"THE_BARRENS":             setRelease("THE_BARRENS", "Forged in the Barrens", 2021, time.March, 30),
+	"STORMWIND":               setRelease("STORMWIND", "United in Stormwind", 2021, time.August, 3),
+	"ALTERAC_VALLEY":          setRelease("ALTERAC_VALLEY", "Fractured in Alterac Valley", 2021, time.December, 7),
+	"THE_SUNKEN_CITY":         setRelease("THE_SUNKEN_CITY", "Voyage to the Sunken City", 2022, time.April, 12),
+	"REVENDRETH":              setRelease("REVENDRETH", "Murder at Castle Nathria", 2022, time.August, 2),
+	"RETURN_OF_THE_LICH_KING": setRelease("RETURN_OF_THE_LICH_KING", "March of the Lich King", 2022, time.December, 6),
+	"PATH_OF_ARTHAS":          setRelease("PATH_OF_ARTHAS", "Path of Arthas", 2022, time.December, 6),
+	"BATTLE_OF_THE_BANDS":     setRelease("BATTLE_OF_THE_BANDS", "Festival of Legends", 2023, time.April, 11),
+	"TITANS":                  setRelease("TITANS", "TITANS", 2023, time.August, 1),
+	"WILD_WEST":               setRelease("WILD_WEST", "Showdown in the Badlands", 2023, time.November, 14),
+	"WHIZBANGS_WORKSHOP":      setRelease("WHIZBANGS_WORKSHOP", "Whizbang's Workshop", 2024, time.March, 19),
+	"ISLAND_VACATION":         setRelease("ISLAND_VACATION", "Perils in Paradise", 2024, time.July, 23),
+	"SPACE":                   setRelease("SPACE", "The Great Dark Beyond", 2024, time.November, 5),
+}
+
+// DeckAge describes when the cards in a deck were released.
+//
+// Newest is the most recently released set among the deck's cards. Cards maps
+// the DBF ID of each card whose set is known to its set's release, and Unknown
+// lists the DBF IDs of cards whose set has no known release, e.g. Core set
+// cards, in ascending order.
+type DeckAge struct {
+	Newest  SetRelease
+	Cards   map[uint64]SetRelease
+	Unknown []uint64
+}
+
+// Predates reports whether every card in the deck with a known set was
+// released before t, e.g. the date of a Standard rotation. A deck without
+// cards of a known set predates any time.
+func (a DeckAge) Predates(t time.Time) bool {
+	return a.Newest.Released.Before(t)
+}
+
+// DeckAge reports the sets the cards in a deck were released in, estimating
+// when the deck was built: a deck can't be older than its newest set.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func (r SetReleases) DeckAge(deck Deck, db *CardDB) (DeckAge, error) {
+	age := DeckAge{Cards: make(map[uint64]SetRelease)}
+	for _, dbfID := range sortedKeys(cardCountsByID(deck.Cards)) {
+		card, err := db.lookup(dbfID)
+		if err != nil {
+			return DeckAge{}, err
+		}
+
+		release, ok := r[card.Set]
+		if !ok {
+			age.Unknown = append(age.Unknown, dbfID)
+			continue
+		}
+
+		age.Cards[dbfID] = release
+		if release.Released.After(age.Newest.Released) {
+			age.Newest = release
+		}
+	}
+	return age, nil
+}
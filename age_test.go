@@ -0,0 +1,44 @@
+package deckstrings_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeckAge(t *testing.T) {
+	db := testCardDB(t)
+
+	// The mage deck's newest card is Kazakus from Mean Streets of Gadgetzan.
+	age, err := DefaultSetReleases.DeckAge(testMageDeck(), db)
+	assert.Nil(t, err)
+	assert.Equal(t, "GANGS", age.Newest.Set)
+	assert.Equal(t, 2016, age.Newest.Year())
+	assert.Equal(t, 2014, age.Cards[315].Year())
+	assert.Equal(t, "Classic", age.Cards[581].Name)
+	assert.Empty(t, age.Unknown)
+
+	assert.True(t, age.Predates(time.Date(2017, time.April, 6, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, age.Predates(time.Date(2016, time.April, 26, 0, 0, 0, 0, time.UTC)))
+
+	deck := Deck{Cards: [][2]uint64{{315, 1}, {64678, 1}, {90749, 2}, {102983, 1}}}
+	age, err = DefaultSetReleases.DeckAge(deck, db)
+	assert.Nil(t, err)
+	assert.Equal(t, "TITANS", age.Newest.Set)
+	assert.Equal(t, []uint64{64678}, age.Unknown)
+	assert.Len(t, age.Cards, 3)
+}
+
+func TestDeckAgeEmpty(t *testing.T) {
+	age, err := DefaultSetReleases.DeckAge(Deck{Cards: [][2]uint64{{64678, 2}}}, testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, SetRelease{}, age.Newest)
+	assert.True(t, age.Predates(time.Now()))
+}
+
+func TestDeckAgeUnknownCard(t *testing.T) {
+	_, err := DefaultSetReleases.DeckAge(Deck{Cards: [][2]uint64{{999999, 1}}}, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
@@ -0,0 +1,93 @@
+package deckstrings
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Archetype is a named deck archetype described by a signature: a weight for
+// each card that indicates how characteristic the card is of the archetype.
+// Positive weights count toward the archetype and negative weights count
+// against it.
+type Archetype struct {
+	Name    string             `json:"name"`
+	Weights map[uint64]float64 `json:"weights"`
+}
+
+// Score rates how well a deck matches the archetype by summing the weights of
+// the cards in the deck and dividing by the sum of the archetype's positive
+// weights. A deck containing every positively weighted card and no negatively
+// weighted cards scores 1. Card counts do not affect the score.
+func (a Archetype) Score(deck Deck) float64 {
+	total := 0.0
+	for _, weight := range a.Weights {
+		if weight > 0 {
+			total += weight
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	score := 0.0
+	for dbfID := range cardCountsByID(deck.Cards) {
+		score += a.Weights[dbfID]
+	}
+
+	return score / total
+}
+
+// ArchetypeMatch is an archetype along with a deck's score for it.
+type ArchetypeMatch struct {
+	Archetype Archetype
+	Score     float64
+}
+
+// Classifier assigns decks to the best-matching of a set of archetypes.
+//
+// MinScore is the minimum score a deck must have for an archetype to be
+// considered a match. See Archetype.Score.
+type Classifier struct {
+	Archetypes []Archetype
+	MinScore   float64
+}
+
+// Classify returns the archetype best matching the deck. It returns false if no
+// archetype scores at least MinScore. Ties are broken by archetype order.
+func (c *Classifier) Classify(deck Deck) (ArchetypeMatch, bool) {
+	matches := c.Matches(deck)
+	if len(matches) == 0 {
+		return ArchetypeMatch{}, false
+	}
+	return matches[0], true
+}
+
+// Matches returns every archetype scoring at least MinScore for the deck,
+// ordered by score descending.
+func (c *Classifier) Matches(deck Deck) []ArchetypeMatch {
+	var matches []ArchetypeMatch
+	for _, archetype := range c.Archetypes {
+		if score := archetype.Score(deck); score >= c.MinScore {
+			matches = append(matches, ArchetypeMatch{Archetype: archetype, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+// LoadArchetypes reads archetype signatures from JSON: an array of objects with
+// a "name" and an object of "weights" keyed by DBF ID, e.g.
+//
+//	[{"name": "Freeze Mage", "weights": {"662": 1.0, "1004": 0.5}}]
+func LoadArchetypes(reader io.Reader) ([]Archetype, error) {
+	var archetypes []Archetype
+	if err := json.NewDecoder(reader).Decode(&archetypes); err != nil {
+		return nil, errors.Wrap(err, "archetypes load")
+	}
+	return archetypes, nil
+}
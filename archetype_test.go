@@ -0,0 +1,54 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+var testArchetypes = `[
+	{"name": "Freeze Mage", "weights": {"662": 1, "1004": 1, "395": 0.5, "7": -1}},
+	{"name": "Big Mage", "weights": {"581": 1, "1004": 1}},
+	{"name": "Control Warrior", "weights": {"1023": 1, "401": 1}}
+]`
+
+func TestLoadArchetypes(t *testing.T) {
+	archetypes, err := LoadArchetypes(strings.NewReader(testArchetypes))
+	assert.Nil(t, err)
+	assert.Len(t, archetypes, 3)
+	assert.Equal(t, "Freeze Mage", archetypes[0].Name)
+	assert.Equal(t, map[uint64]float64{662: 1, 1004: 1, 395: 0.5, 7: -1}, archetypes[0].Weights)
+
+	_, err = LoadArchetypes(strings.NewReader(`[{"weights": {"x": 1}}]`))
+	assert.NotNil(t, err)
+}
+
+func TestArchetypeScore(t *testing.T) {
+	archetype := Archetype{Name: "A", Weights: map[uint64]float64{1: 1, 2: 1, 3: -1}}
+
+	assert.Equal(t, 1.0, archetype.Score(Deck{Cards: [][2]uint64{{1, 2}, {2, 1}}}))
+	assert.Equal(t, 0.5, archetype.Score(Deck{Cards: [][2]uint64{{1, 2}}}))
+	assert.Equal(t, 0.0, archetype.Score(Deck{Cards: [][2]uint64{{1, 2}, {3, 1}}}))
+	assert.Equal(t, 0.0, Archetype{}.Score(Deck{Cards: [][2]uint64{{1, 2}}}))
+}
+
+func TestClassify(t *testing.T) {
+	archetypes, err := LoadArchetypes(strings.NewReader(testArchetypes))
+	assert.Nil(t, err)
+
+	classifier := &Classifier{Archetypes: archetypes, MinScore: 0.5}
+
+	match, ok := classifier.Classify(testMageDeck())
+	assert.True(t, ok)
+	assert.Equal(t, "Freeze Mage", match.Archetype.Name)
+	assert.Equal(t, 1.0, match.Score)
+
+	matches := classifier.Matches(testMageDeck())
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "Big Mage", matches[1].Archetype.Name)
+
+	_, ok = classifier.Classify(Deck{Cards: [][2]uint64{{77, 2}}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,64 @@
+package deckstrings
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DecodeAll decodes deckstrings in parallel, e.g. for backfill jobs over large
+// numbers of stored deckstrings. It returns a deck and an error for each
+// deckstring, in input order: if deckstrings[i] can't be decoded, errs[i] is
+// set and decks[i] is empty. Options are passed to Decode for each
+// deckstring; use DecodeWorkers to set the number of workers.
+func DecodeAll(deckstrings []string, opts ...DecodeOption) (decks []Deck, errs []error) {
+	decks = make([]Deck, len(deckstrings))
+	errs = make([]error, len(deckstrings))
+	parallel(len(deckstrings), newDecodeOptions(opts).workers, func(i int) {
+		decks[i], errs[i] = Decode(deckstrings[i], opts...)
+	})
+	return decks, errs
+}
+
+// EncodeAll is like DecodeAll for encoding, e.g. for bulk export jobs. It
+// returns a deckstring and an error for each deck, in input order: if decks[i]
+// can't be encoded, errs[i] is set and deckstrings[i] is empty. Options are
+// passed to Encode for each deck; use EncodeWorkers to set the number of
+// workers.
+func EncodeAll(decks []Deck, opts ...EncodeOption) (deckstrings []string, errs []error) {
+	deckstrings = make([]string, len(decks))
+	errs = make([]error, len(decks))
+	parallel(len(decks), newEncodeOptions(opts).workers, func(i int) {
+		deckstrings[i], errs[i] = Encode(decks[i], opts...)
+	})
+	return deckstrings, errs
+}
+
+// parallel calls fn for each index in [0, n) across a pool of workers, or
+// runtime.GOMAXPROCS(0) workers if workers is less than 1, returning once
+// every call has returned.
+func parallel(n int, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
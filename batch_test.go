@@ -0,0 +1,80 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAll(t *testing.T) {
+	valid := []string{
+		"AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=",
+		"AAEBAQcAAAQBAwIDAwMEAw==",
+	}
+
+	var deckstrings []string
+	for i := 0; i < 100; i++ {
+		deckstrings = append(deckstrings, valid[i%2])
+	}
+	deckstrings[50] = "AAEC"
+
+	for _, workers := range []int{0, 1, 7, 1000} {
+		decks, errs := DecodeAll(deckstrings, DecodeWorkers(workers))
+		require.Len(t, decks, len(deckstrings))
+		require.Len(t, errs, len(deckstrings))
+		for i, deckstring := range deckstrings {
+			if i == 50 {
+				assert.NotNil(t, errs[i])
+				assert.Equal(t, Deck{}, decks[i])
+				continue
+			}
+			assert.Nil(t, errs[i])
+			assert.Equal(t, MustDecode(deckstring), decks[i])
+		}
+	}
+}
+
+func TestDecodeAllOptions(t *testing.T) {
+	decks, errs := DecodeAll([]string{"AAEBAQcAAAQBAwIDAwMEAw=="}, RequireFormat(FormatStandard))
+	assert.Equal(t, []Deck{{}}, decks)
+	assert.NotNil(t, errs[0])
+
+	decks, errs = DecodeAll(nil)
+	assert.Empty(t, decks)
+	assert.Empty(t, errs)
+}
+
+func TestEncodeAll(t *testing.T) {
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	expected, err := Encode(deck)
+	require.Nil(t, err)
+
+	decks := make([]Deck, 100)
+	for i := range decks {
+		decks[i] = deck
+		decks[i].Format = Format(i%2 + 1)
+	}
+	decks[50] = Deck{Format: FormatWild, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 0}}}
+
+	for _, workers := range []int{0, 1, 7, 1000} {
+		deckstrings, errs := EncodeAll(decks, EncodeWorkers(workers))
+		require.Len(t, deckstrings, len(decks))
+		require.Len(t, errs, len(decks))
+		for i, deckstring := range deckstrings {
+			if i == 50 {
+				assert.NotNil(t, errs[i])
+				assert.Equal(t, "", deckstring)
+				continue
+			}
+			assert.Nil(t, errs[i])
+			assert.Equal(t, decks[i], MustDecode(deckstring))
+		}
+		assert.Equal(t, expected, deckstrings[1])
+	}
+
+	deckstrings, errs := EncodeAll(nil)
+	assert.Empty(t, deckstrings)
+	assert.Empty(t, errs)
+}
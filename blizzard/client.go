@@ -0,0 +1,160 @@
+// Package blizzard is a client for Blizzard's official Hearthstone Deck API,
+// which hydrates a deckstring into full hero and card metadata. It is an
+// alternative to HearthstoneJSON for consumers who prefer official data.
+//
+// Requests are authorized with an OAuth access token. Use ClientCredentials
+// to obtain and refresh tokens with a client ID and secret registered at
+// https://develop.battle.net/, or StaticToken for a token obtained elsewhere.
+//
+//	client := blizzard.NewClient(&blizzard.ClientCredentials{ID: id, Secret: secret})
+//	deck, err := client.Deck(ctx, "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+package blizzard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/schmich/deckstrings"
+)
+
+// DefaultRegion and DefaultLocale are used by clients that don't set Region
+// or Locale.
+const (
+	DefaultRegion = "us"
+	DefaultLocale = "en_US"
+)
+
+// Client requests decks from the Hearthstone Deck API. The zero value is not
+// usable; create clients with NewClient. A Client is safe for concurrent use
+// as long as its fields are not modified.
+type Client struct {
+	// Tokens provides the OAuth access token for each request.
+	Tokens TokenSource
+
+	// Region is the API region, e.g. "us", "eu", "kr", or "tw".
+	Region string
+
+	// Locale is the locale of card names and text, e.g. "en_US" or "de_DE".
+	Locale string
+
+	// BaseURL overrides the API endpoint derived from Region, e.g. for tests.
+	BaseURL string
+
+	// HTTPClient is used for requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Logger, if set, logs each request: its status and duration at
+	// slog.LevelDebug, and failures at slog.LevelWarn.
+	Logger *slog.Logger
+
+	// Metrics, if set, observes each request as the operation "blizzard deck".
+	Metrics deckstrings.Metrics
+}
+
+// NewClient creates a client using tokens with the default region and locale.
+func NewClient(tokens TokenSource) *Client {
+	return &Client{Tokens: tokens, Region: DefaultRegion, Locale: DefaultLocale}
+}
+
+// Deck looks up a deckstring and returns the deck with full hero and card
+// metadata.
+func (c *Client) Deck(ctx context.Context, deckstring string) (deck *Deck, err error) {
+	start := time.Now()
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "blizzard deck")
+		}
+		duration := time.Since(start)
+		if c.Metrics != nil {
+			c.Metrics.ObserveRequest("blizzard deck", duration, err)
+		}
+		if c.Logger != nil {
+			if err != nil {
+				c.Logger.Warn("blizzard deck request failed", "deckstring", deckstring, "duration", duration, "error", err.Error())
+			} else {
+				c.Logger.Debug("blizzard deck request", "deckstring", deckstring, "duration", duration)
+			}
+		}
+	}()
+
+	token, err := c.Tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"code": {deckstring}, "locale": {c.locale()}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/hearthstone/deck?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := c.httpClient().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newAPIError(response)
+	}
+
+	deck = &Deck{}
+	if err := json.NewDecoder(response.Body).Decode(deck); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+
+	region := c.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+	return "https://" + region + ".api.blizzard.com"
+}
+
+func (c *Client) locale() string {
+	if c.Locale == "" {
+		return DefaultLocale
+	}
+	return c.Locale
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// APIError is returned when the API responds with an error status, e.g. 404
+// for a deckstring it can't decode or 401 for an expired token.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("API error: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// maxErrorBody is the most of an error response kept in APIError.
+const maxErrorBody = 1024
+
+func newAPIError(response *http.Response) APIError {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	return APIError{StatusCode: response.StatusCode, Body: string(body)}
+}
@@ -0,0 +1,190 @@
+package blizzard_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/blizzard"
+	"github.com/stretchr/testify/assert"
+)
+
+const deckstring = "AAEBAQcAAAQBAwIDAwMEAw=="
+
+const deckJSON = `{
+	"deckCode": "AAEBAQcAAAQBAwIDAwMEAw==",
+	"version": 1,
+	"format": "wild",
+	"hero": {"id": 7, "name": "Garrosh Hellscream", "collectible": 1, "rarityId": 2},
+	"class": {"id": 10, "slug": "warrior", "name": "Warrior"},
+	"cards": [
+		{"id": 1, "name": "One", "manaCost": 1, "rarityId": 1, "collectible": 1},
+		{"id": 2, "name": "Two", "manaCost": 2, "rarityId": 5, "collectible": 1}
+	],
+	"cardCount": 2
+}`
+
+func testServer(t *testing.T, tokenRequests *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		*tokenRequests++
+		id, secret, _ := r.BasicAuth()
+		if id != "id" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+			http.Error(w, "bad credentials", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "token", "token_type": "bearer", "expires_in": 86399}`)
+	})
+	mux.HandleFunc("/hearthstone/deck", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("code") != deckstring || r.URL.Query().Get("locale") != "de_DE" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, deckJSON)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientDeck(t *testing.T) {
+	var tokenRequests int
+	server := testServer(t, &tokenRequests)
+
+	tokens := &blizzard.ClientCredentials{ID: "id", Secret: "secret", TokenURL: server.URL + "/token"}
+	client := blizzard.NewClient(tokens)
+	client.BaseURL = server.URL
+	client.Locale = "de_DE"
+
+	for i := 0; i < 2; i++ {
+		deck, err := client.Deck(context.Background(), deckstring)
+		assert.Nil(t, err)
+		assert.Equal(t, "Garrosh Hellscream", deck.Hero.Name)
+		assert.Equal(t, "warrior", deck.Class.Slug)
+		assert.Len(t, deck.Cards, 2)
+	}
+
+	// Tokens are cached.
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestClientDeckErrors(t *testing.T) {
+	var tokenRequests int
+	server := testServer(t, &tokenRequests)
+
+	client := blizzard.NewClient(blizzard.StaticToken("expired"))
+	client.BaseURL = server.URL
+	_, err := client.Deck(context.Background(), deckstring)
+	assert.EqualError(t, err, "blizzard deck: API error: 401 Unauthorized: unauthorized\n")
+	assert.Equal(t, http.StatusUnauthorized, errors.Cause(err).(blizzard.APIError).StatusCode)
+
+	client = blizzard.NewClient(&blizzard.ClientCredentials{ID: "id", Secret: "wrong", TokenURL: server.URL + "/token"})
+	client.BaseURL = server.URL
+	_, err = client.Deck(context.Background(), deckstring)
+	assert.EqualError(t, err, "blizzard deck: blizzard token: API error: 401 Unauthorized: bad credentials\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client = blizzard.NewClient(blizzard.StaticToken("token"))
+	client.BaseURL = server.URL
+	_, err = client.Deck(ctx, deckstring)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+}
+
+func TestClientLogger(t *testing.T) {
+	var tokenRequests int
+	server := testServer(t, &tokenRequests)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tokens := &blizzard.ClientCredentials{ID: "id", Secret: "secret", TokenURL: server.URL + "/token", Logger: logger}
+	client := blizzard.NewClient(tokens)
+	client.BaseURL = server.URL
+	client.Locale = "de_DE"
+	client.Logger = logger
+
+	_, err := client.Deck(context.Background(), deckstring)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="blizzard token refreshed" expires_in=`)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="blizzard deck request" deckstring="`+deckstring+`"`)
+
+	buf.Reset()
+	client.Locale = "en_US"
+	_, err = client.Deck(context.Background(), deckstring)
+	assert.NotNil(t, err)
+	assert.NotContains(t, buf.String(), "token refreshed")
+	assert.Contains(t, buf.String(), `level=WARN msg="blizzard deck request failed"`)
+	assert.Contains(t, buf.String(), `error="blizzard deck: API error: 404 Not Found`)
+
+	buf.Reset()
+	tokens = &blizzard.ClientCredentials{ID: "id", Secret: "wrong", TokenURL: server.URL + "/token", Logger: logger}
+	_, err = tokens.Token(context.Background())
+	assert.NotNil(t, err)
+	assert.Contains(t, buf.String(), `level=WARN msg="blizzard token request failed"`)
+}
+
+func TestDeckConversion(t *testing.T) {
+	var tokenRequests int
+	server := testServer(t, &tokenRequests)
+
+	client := blizzard.NewClient(blizzard.StaticToken("token"))
+	client.BaseURL = server.URL
+	client.Locale = "de_DE"
+	deck, err := client.Deck(context.Background(), deckstring)
+	assert.Nil(t, err)
+
+	decoded, err := deck.Deck()
+	assert.Nil(t, err)
+	assert.Equal(t, deckstrings.MustDecode(deckstring), decoded)
+
+	db := deck.CardDB()
+	assert.Equal(t, 3, db.Len())
+	card, ok := db.Card(2)
+	assert.True(t, ok)
+	assert.Equal(t, deckstrings.CardInfo{DBFID: 2, Name: "Two", Cost: 2, Rarity: deckstrings.RarityLegendary, Collectible: true}, card)
+}
+
+type requestMetrics map[string][]error
+
+func (m requestMetrics) ObserveDecode(time.Duration, error) {}
+func (m requestMetrics) ObserveEncode(time.Duration, error) {}
+
+func (m requestMetrics) ObserveRequest(operation string, duration time.Duration, err error) {
+	m[operation] = append(m[operation], err)
+}
+
+func TestClientMetrics(t *testing.T) {
+	var tokenRequests int
+	server := testServer(t, &tokenRequests)
+
+	metrics := requestMetrics{}
+	tokens := &blizzard.ClientCredentials{ID: "id", Secret: "secret", TokenURL: server.URL + "/token", Metrics: metrics}
+	client := blizzard.NewClient(tokens)
+	client.BaseURL = server.URL
+	client.Locale = "de_DE"
+	client.Metrics = metrics
+
+	_, err := client.Deck(context.Background(), deckstring)
+	assert.Nil(t, err)
+	client.Locale = "en_US"
+	_, err = client.Deck(context.Background(), deckstring)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []error{nil}, metrics["blizzard token"])
+	assert.Len(t, metrics["blizzard deck"], 2)
+	assert.Nil(t, metrics["blizzard deck"][0])
+	assert.Equal(t, http.StatusNotFound, errors.Cause(metrics["blizzard deck"][1]).(blizzard.APIError).StatusCode)
+}
@@ -0,0 +1,79 @@
+package blizzard
+
+import (
+	"github.com/schmich/deckstrings"
+)
+
+// Deck is a deck as returned by the Hearthstone Deck API. Cards lists each
+// copy of a card separately.
+type Deck struct {
+	DeckCode  string `json:"deckCode"`
+	Version   int    `json:"version"`
+	Format    string `json:"format"`
+	Hero      Card   `json:"hero"`
+	HeroPower Card   `json:"heroPower"`
+	Class     Class  `json:"class"`
+	Cards     []Card `json:"cards"`
+	CardCount int    `json:"cardCount"`
+}
+
+// Class is a Hearthstone class, e.g. Slug "mage".
+type Class struct {
+	ID   int    `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Card is a card or hero as returned by the Hearthstone Deck API. ID is the
+// card's DBF ID. Rarity, set, class, and type are given as IDs defined by
+// the API's metadata endpoint.
+type Card struct {
+	ID            uint64 `json:"id"`
+	Collectible   int    `json:"collectible"`
+	Slug          string `json:"slug"`
+	ClassID       int    `json:"classId"`
+	MultiClassIDs []int  `json:"multiClassIds"`
+	CardTypeID    int    `json:"cardTypeId"`
+	CardSetID     int    `json:"cardSetId"`
+	RarityID      int    `json:"rarityId"`
+	ArtistName    string `json:"artistName"`
+	Health        int    `json:"health"`
+	Attack        int    `json:"attack"`
+	ManaCost      int    `json:"manaCost"`
+	Name          string `json:"name"`
+	Text          string `json:"text"`
+	Image         string `json:"image"`
+	FlavorText    string `json:"flavorText"`
+}
+
+// rarities maps the API's rarity IDs to HearthstoneJSON rarities.
+var rarities = map[int]deckstrings.Rarity{
+	1: deckstrings.RarityCommon,
+	2: deckstrings.RarityFree,
+	3: deckstrings.RarityRare,
+	4: deckstrings.RarityEpic,
+	5: deckstrings.RarityLegendary,
+}
+
+// Deck returns the deck as a deckstrings.Deck by decoding its deck code.
+func (d *Deck) Deck() (deckstrings.Deck, error) {
+	return deckstrings.Decode(d.DeckCode)
+}
+
+// CardDB returns a card database holding the deck's hero and cards, for use
+// with functions like deckstrings.ManaCurve in place of a database loaded from
+// HearthstoneJSON. The API identifies sets, classes, and types by numeric IDs
+// only, so CardInfo's Set, Class, and Type are left empty; ID is empty too.
+func (d *Deck) CardDB() *deckstrings.CardDB {
+	cards := make([]deckstrings.CardInfo, 0, len(d.Cards)+1)
+	for _, card := range append([]Card{d.Hero}, d.Cards...) {
+		cards = append(cards, deckstrings.CardInfo{
+			DBFID:       card.ID,
+			Name:        card.Name,
+			Cost:        card.ManaCost,
+			Rarity:      rarities[card.RarityID],
+			Collectible: card.Collectible == 1,
+		})
+	}
+	return deckstrings.NewCardDB(cards)
+}
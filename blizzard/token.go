@@ -0,0 +1,133 @@
+package blizzard
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/schmich/deckstrings"
+)
+
+// TokenSource provides OAuth access tokens for API requests.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token.
+type StaticToken string
+
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// DefaultTokenURL is Blizzard's OAuth token endpoint.
+const DefaultTokenURL = "https://oauth.battle.net/token"
+
+// expiryMargin is how long before its expiry a token is refreshed.
+const expiryMargin = time.Minute
+
+// ClientCredentials is a TokenSource that obtains tokens with the OAuth client
+// credentials flow, caching each token until shortly before it expires. It is
+// safe for concurrent use.
+type ClientCredentials struct {
+	ID     string
+	Secret string
+
+	// TokenURL overrides DefaultTokenURL.
+	TokenURL string
+
+	// HTTPClient is used for token requests. If nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+
+	// Logger, if set, logs token refreshes at slog.LevelDebug and failures
+	// at slog.LevelWarn.
+	Logger *slog.Logger
+
+	// Metrics, if set, observes each token request as the operation
+	// "blizzard token". Cached tokens are not observed.
+	Metrics deckstrings.Metrics
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// Token returns a cached token or requests a new one.
+func (c *ClientCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.expiry) {
+		return c.token, nil
+	}
+
+	start := time.Now()
+	token, expiresIn, err := c.request(ctx)
+	if c.Metrics != nil {
+		c.Metrics.ObserveRequest("blizzard token", time.Since(start), err)
+	}
+	if err != nil {
+		err = errors.Wrap(err, "blizzard token")
+		if c.Logger != nil {
+			c.Logger.Warn("blizzard token request failed", "error", err.Error())
+		}
+		return "", err
+	}
+	if c.Logger != nil {
+		c.Logger.Debug("blizzard token refreshed", "expires_in", expiresIn)
+	}
+
+	c.token = token
+	c.expiry = time.Now().Add(expiresIn - expiryMargin)
+	return token, nil
+}
+
+func (c *ClientCredentials) request(ctx context.Context) (string, time.Duration, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(c.ID, c.Secret)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", 0, newAPIError(response)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", 0, err
+	}
+	if body.AccessToken == "" {
+		return "", 0, errors.New("no access token in response")
+	}
+
+	return body.AccessToken, time.Duration(body.ExpiresIn) * time.Second, nil
+}
@@ -0,0 +1,118 @@
+package deckstrings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Builder constructs a deck through chained calls, validating each step:
+//
+//	deck, err := deckstrings.NewBuilder().
+//		Format(deckstrings.FormatStandard).
+//		Hero(274).
+//		Card(64, 2).
+//		Card(95, 1).
+//		Build()
+//
+// Invalid steps are recorded rather than aborting the chain, so Build reports
+// every problem at once as a BuildError. Adding the same card more than once
+// sums its counts.
+type Builder struct {
+	format Format
+	heroes []uint64
+	counts map[uint64]uint64
+	errors []error
+}
+
+// NewBuilder returns an empty deck builder.
+func NewBuilder() *Builder {
+	return &Builder{counts: make(map[uint64]uint64)}
+}
+
+// Format sets the deck's format. The format must be known; see Format.Known.
+func (b *Builder) Format(format Format) *Builder {
+	if !format.Known() {
+		b.errorf("unknown format: %s", format)
+		return b
+	}
+	b.format = format
+	return b
+}
+
+// Hero adds a hero to the deck. The DBF ID must be nonzero and each hero may
+// only be added once.
+func (b *Builder) Hero(dbfID uint64) *Builder {
+	if dbfID == 0 {
+		b.errorf("invalid hero DBF ID: 0")
+		return b
+	}
+	for _, hero := range b.heroes {
+		if hero == dbfID {
+			b.errorf("duplicate hero: DBF ID %d", dbfID)
+			return b
+		}
+	}
+	b.heroes = append(b.heroes, dbfID)
+	return b
+}
+
+// Card adds count copies of a card to the deck. The DBF ID and count must be
+// nonzero.
+func (b *Builder) Card(dbfID, count uint64) *Builder {
+	if dbfID == 0 {
+		b.errorf("invalid card DBF ID: 0")
+		return b
+	}
+	if count < 1 {
+		b.errorf("invalid card count for DBF ID %d: %d", dbfID, count)
+		return b
+	}
+	b.counts[dbfID] += count
+	return b
+}
+
+// Build returns the canonical deck, or a BuildError listing every invalid step
+// along with a missing format or hero.
+func (b *Builder) Build() (Deck, error) {
+	errs := append([]error(nil), b.errors...)
+	if b.format == 0 {
+		errs = append(errs, errors.New("format not set"))
+	}
+	if len(b.heroes) == 0 {
+		errs = append(errs, errors.New("no heroes"))
+	}
+	if len(errs) > 0 {
+		return Deck{}, BuildError{Errors: errs}
+	}
+
+	deck := Deck{
+		Format: b.format,
+		Heroes: sortedHeroes(b.heroes),
+		Cards:  make([][2]uint64, 0, len(b.counts)),
+	}
+	for _, dbfID := range sortedKeys(b.counts) {
+		deck.Cards = append(deck.Cards, [2]uint64{dbfID, b.counts[dbfID]})
+	}
+
+	return deck, nil
+}
+
+func (b *Builder) errorf(format string, args ...interface{}) {
+	b.errors = append(b.errors, fmt.Errorf(format, args...))
+}
+
+// BuildError is returned by Builder.Build and lists every problem found while
+// building the deck, in the order they occurred.
+type BuildError struct {
+	Errors []error
+}
+
+func (e BuildError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return "deck build: " + strings.Join(messages, "; ")
+}
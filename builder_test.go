@@ -0,0 +1,49 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	deck, err := NewBuilder().
+		Format(FormatStandard).
+		Hero(274).
+		Card(95, 1).
+		Card(64, 2).
+		Card(95, 1).
+		Build()
+
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{274},
+		Cards:  [][2]uint64{{64, 2}, {95, 2}},
+	}, deck)
+}
+
+func TestBuilderErrors(t *testing.T) {
+	_, err := NewBuilder().
+		Format(Format(9)).
+		Hero(0).
+		Card(64, 0).
+		Card(0, 1).
+		Build()
+
+	assert.IsType(t, BuildError{}, err)
+	assert.Len(t, err.(BuildError).Errors, 6)
+	assert.Equal(t, "deck build: unknown format: Format(9); invalid hero DBF ID: 0; "+
+		"invalid card count for DBF ID 64: 0; invalid card DBF ID: 0; format not set; no heroes", err.Error())
+}
+
+func TestBuilderDuplicateHero(t *testing.T) {
+	_, err := NewBuilder().
+		Format(FormatWild).
+		Hero(7).
+		Hero(7).
+		Build()
+
+	assert.Equal(t, "deck build: duplicate hero: DBF ID 7", err.Error())
+}
@@ -0,0 +1,140 @@
+package deckstrings
+
+import (
+	"container/list"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// DecodeCache memoizes the results of Decode, keeping the most recently used
+// deckstrings up to a fixed size. Web services often decode the same popular
+// deckstrings many times, and a cache hit skips base64 decoding, parsing, and
+// validation entirely. Failed decodes are cached too, so repeated invalid
+// input is rejected just as cheaply. Deckstrings longer than the base64
+// encoding of the maximum payload length (see MaxPayloadLength) are decoded
+// but never cached, bounding the cache's memory regardless of its input.
+//
+// A DecodeCache is safe for concurrent use.
+type DecodeCache struct {
+	opts      []DecodeOption
+	size      int
+	ttl       time.Duration
+	maxLength int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type decodeCacheEntry struct {
+	deckstring string
+	deck       Deck
+	err        error
+	expiry     time.Time
+}
+
+// NewDecodeCache creates a DecodeCache holding up to size results, each
+// decoded with opts. A size less than 1 is treated as 1. If ttl is positive,
+// results older than ttl are decoded again, e.g. so that changes to options
+// that depend on external state take effect; 0 keeps results until they are
+// evicted.
+func NewDecodeCache(size int, ttl time.Duration, opts ...DecodeOption) *DecodeCache {
+	if size < 1 {
+		size = 1
+	}
+
+	// Without a payload limit, bound cached deckstrings by the default limit.
+	maxPayloadLength := newDecodeOptions(opts).maxPayloadLength
+	if maxPayloadLength <= 0 {
+		maxPayloadLength = DefaultMaxPayloadLength
+	}
+
+	return &DecodeCache{
+		opts:      opts,
+		size:      size,
+		ttl:       ttl,
+		maxLength: base64.StdEncoding.EncodedLen(maxPayloadLength),
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+	}
+}
+
+// Decode is like Decode with the cache's options, returning a cached result
+// if there is one. The returned deck is a copy that the caller may modify.
+func (c *DecodeCache) Decode(deckstring string) (Deck, error) {
+	if len(deckstring) > c.maxLength {
+		return Decode(deckstring, c.opts...)
+	}
+
+	if deck, err, ok := c.get(deckstring); ok {
+		return deck.Clone(), err
+	}
+
+	// Decode without holding the lock. Concurrent misses for the same
+	// deckstring each decode it, which is harmless.
+	deck, err := Decode(deckstring, c.opts...)
+	c.add(deckstring, deck, err)
+	return deck.Clone(), err
+}
+
+func (c *DecodeCache) get(deckstring string) (Deck, error, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[deckstring]
+	if !ok {
+		return Deck{}, nil, false
+	}
+
+	entry := element.Value.(*decodeCacheEntry)
+	if c.ttl > 0 && time.Now().After(entry.expiry) {
+		c.remove(element)
+		return Deck{}, nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.deck, entry.err, true
+}
+
+func (c *DecodeCache) add(deckstring string, deck Deck, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &decodeCacheEntry{deckstring: deckstring, deck: deck, err: err}
+	if c.ttl > 0 {
+		entry.expiry = time.Now().Add(c.ttl)
+	}
+
+	if element, ok := c.entries[deckstring]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[deckstring] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *DecodeCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*decodeCacheEntry).deckstring)
+}
+
+// Len returns the number of results in the cache, including any that have
+// expired but not yet been removed.
+func (c *DecodeCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Purge removes all results from the cache.
+func (c *DecodeCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
@@ -0,0 +1,96 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCache(t *testing.T) {
+	metrics := &recordingMetrics{}
+	RegisterMetrics(metrics)
+	t.Cleanup(func() { RegisterMetrics(nil) })
+
+	cache := NewDecodeCache(2, 0)
+	a := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	b := "AAEBAQcAAAQBAwIDAwMEAw=="
+
+	deck, err := cache.Decode(a)
+	require.Nil(t, err)
+	assert.Equal(t, MustDecode(a), deck)
+
+	// Returned decks don't share the cached deck's memory.
+	deck.Cards[0][1] = 9
+	deck, err = cache.Decode(a)
+	require.Nil(t, err)
+	assert.Equal(t, MustDecode(a), deck)
+
+	_, err = cache.Decode("AAEC")
+	assert.NotNil(t, err)
+	_, err2 := cache.Decode("AAEC")
+	assert.Equal(t, err, err2)
+	assert.Equal(t, 2, cache.Len())
+
+	// a is least recently used, so adding b evicts it.
+	_, err = cache.Decode(b)
+	require.Nil(t, err)
+	assert.Equal(t, 2, cache.Len())
+
+	metrics.observations = nil
+	cache.Decode("AAEC")
+	cache.Decode(b)
+	assert.Empty(t, metrics.observations)
+	cache.Decode(a)
+	assert.Len(t, metrics.observations, 1)
+
+	cache.Purge()
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestDecodeCacheTTL(t *testing.T) {
+	metrics := &recordingMetrics{}
+	RegisterMetrics(metrics)
+	t.Cleanup(func() { RegisterMetrics(nil) })
+
+	deckstring := "AAEBAQcAAAQBAwIDAwMEAw=="
+	cache := NewDecodeCache(10, 10*time.Millisecond)
+	cache.Decode(deckstring)
+	cache.Decode(deckstring)
+	assert.Len(t, metrics.observations, 1)
+
+	time.Sleep(20 * time.Millisecond)
+	cache.Decode(deckstring)
+	assert.Len(t, metrics.observations, 2)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestDecodeCacheOptions(t *testing.T) {
+	cache := NewDecodeCache(0, 0, RequireFormat(FormatStandard))
+	_, err := cache.Decode("AAEBAQcAAAQBAwIDAwMEAw==")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestDecodeCacheLongInput(t *testing.T) {
+	cache := NewDecodeCache(10, 0, MaxPayloadLength(3))
+	deckstring := "AAEBAQcAAAQBAwIDAwMEAw=="
+	_, err := cache.Decode(deckstring)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, cache.Len())
+
+	_, err = cache.Decode(strings.Repeat("A", 1024*1024))
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, cache.Len())
+
+	// Without a payload limit, the default limit bounds cached input.
+	cache = NewDecodeCache(10, 0, MaxPayloadLength(0))
+	_, err = cache.Decode(deckstring)
+	assert.Nil(t, err)
+	_, err = cache.Decode(strings.Repeat("A", 1024*1024))
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, cache.Len())
+}
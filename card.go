@@ -0,0 +1,46 @@
+package deckstrings
+
+import "fmt"
+
+// Card is a card in a deck: its DBF ID and the number of copies. It is a more
+// readable alternative to the (DBF ID, count) pairs in Deck.Cards; use
+// Deck.CardList and NewDeck to convert between the two.
+type Card struct {
+	DBFID DBFID
+	Count uint64
+}
+
+// String returns the card as count and DBF ID, e.g. "2x 315", or "2x Fireball
+// (315)" when a card database is registered. See DBFID.
+func (c Card) String() string {
+	return fmt.Sprintf("%dx %s", c.Count, c.DBFID)
+}
+
+// pair returns the card as a (DBF ID, count) pair.
+func (c Card) pair() [2]uint64 {
+	return [2]uint64{uint64(c.DBFID), c.Count}
+}
+
+// NewDeck creates a deck from a list of cards, preserving their order. Use
+// Canonicalize to sort and merge the result.
+func NewDeck(format Format, heroes []uint64, cards []Card) Deck {
+	deck := Deck{
+		Format: format,
+		Heroes: append([]uint64{}, heroes...),
+		Cards:  make([][2]uint64, len(cards)),
+	}
+	for i, card := range cards {
+		deck.Cards[i] = card.pair()
+	}
+	return deck
+}
+
+// CardList returns the deck's cards as a list of Card, in the order stored in
+// the deck.
+func (d Deck) CardList() []Card {
+	cards := make([]Card, len(d.Cards))
+	for i, card := range d.Cards {
+		cards[i] = Card{DBFID: DBFID(card[0]), Count: card[1]}
+	}
+	return cards
+}
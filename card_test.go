@@ -0,0 +1,29 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardList(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{455, 1}, {141, 2}}}
+	assert.Equal(t, []Card{{DBFID: 455, Count: 1}, {DBFID: 141, Count: 2}}, deck.CardList())
+	assert.Equal(t, []Card{}, Deck{}.CardList())
+}
+
+func TestNewDeck(t *testing.T) {
+	cards := []Card{{DBFID: 455, Count: 1}, {DBFID: 141, Count: 2}}
+	deck := NewDeck(FormatStandard, []uint64{31}, cards)
+	assert.Equal(t, Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{455, 1}, {141, 2}},
+	}, deck)
+	assert.Equal(t, cards, deck.CardList())
+}
+
+func TestCardString(t *testing.T) {
+	assert.Equal(t, "2x 315", Card{DBFID: 315, Count: 2}.String())
+}
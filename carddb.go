@@ -0,0 +1,277 @@
+package deckstrings
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Rarity is a card rarity as named by HearthstoneJSON, e.g. "LEGENDARY".
+type Rarity string
+
+const (
+	RarityFree      Rarity = "FREE"
+	RarityCommon    Rarity = "COMMON"
+	RarityRare      Rarity = "RARE"
+	RarityEpic      Rarity = "EPIC"
+	RarityLegendary Rarity = "LEGENDARY"
+)
+
+// CardInfo is metadata for a single Hearthstone card. String-valued fields use
+// the enum names found in HearthstoneJSON, e.g. Type "MINION", Class "MAGE",
+// Set "EXPERT1", SpellSchool "FIRE", Races ["DRAGON"], Mechanics ["TAUNT"].
+//
+// Class is the card's primary class, or "NEUTRAL". Classes is set only for
+// multi-class cards and lists every class that can play the card.
+//
+// Name is the card's English name when known. Names is set for cards loaded
+// from HearthstoneJSON's all-locales data and maps locales like "deDE" to the
+// card's name in that locale.
+type CardInfo struct {
+	DBFID       uint64
+	ID          string
+	Name        string
+	Names       map[string]string
+	Cost        int
+	Rarity      Rarity
+	Set         string
+	Type        string
+	Class       string
+	Classes     []string
+	SpellSchool string
+	Races       []string
+	Mechanics   []string
+	Collectible bool
+}
+
+// CardDB is an in-memory database of card metadata indexed by DBF ID.
+//
+// A CardDB is typically loaded from the HearthstoneJSON cards.json file with
+// LoadCardDB. See https://hearthstonejson.com/ for details. A CardDB is safe for
+// concurrent use once constructed.
+type CardDB struct {
+	cards  map[uint64]CardInfo
+	byID   map[string]uint64
+	byName map[string][]uint64
+}
+
+// NewCardDB creates a card database from the given cards. Later cards replace
+// earlier cards with the same DBF ID.
+func NewCardDB(cards []CardInfo) *CardDB {
+	db := &CardDB{cards: make(map[uint64]CardInfo, len(cards))}
+	for _, card := range cards {
+		db.cards[card.DBFID] = card
+	}
+
+	// Index in DBF ID order so cards sharing a name are listed in that order.
+	ids := make([]uint64, 0, len(db.cards))
+	for dbfID := range db.cards {
+		ids = append(ids, dbfID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	db.byID = make(map[string]uint64, len(db.cards))
+	db.byName = make(map[string][]uint64)
+	for _, dbfID := range ids {
+		card := db.cards[dbfID]
+		if card.ID != "" {
+			db.byID[card.ID] = dbfID
+		}
+		for _, name := range card.names() {
+			key := strings.ToLower(name)
+			db.byName[key] = append(db.byName[key], dbfID)
+		}
+	}
+	return db
+}
+
+// names returns the card's distinct names across all locales.
+func (c CardInfo) names() []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if key := strings.ToLower(name); name != "" && !seen[key] {
+			seen[key] = true
+			names = append(names, name)
+		}
+	}
+
+	add(c.Name)
+	for _, name := range c.Names {
+		add(name)
+	}
+	return names
+}
+
+// LoadCardDB reads a card database from HearthstoneJSON's cards.json format:
+// a JSON array of card objects.
+//
+// Both the per-locale files, whose card names are strings, and the
+// all-locales file, whose card names are objects keyed by locale, are
+// supported. Cards from the all-locales file can be looked up by name in any
+// locale.
+func LoadCardDB(reader io.Reader) (*CardDB, error) {
+	var entries []hearthstoneJSONCard
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return nil, errors.Wrap(err, "card database load")
+	}
+
+	cards := make([]CardInfo, 0, len(entries))
+	for _, entry := range entries {
+		cards = append(cards, entry.cardInfo())
+	}
+
+	return NewCardDB(cards), nil
+}
+
+// Card returns the metadata for the card with the given DBF ID.
+func (db *CardDB) Card(dbfID uint64) (CardInfo, bool) {
+	card, ok := db.cards[dbfID]
+	return card, ok
+}
+
+// CardByID returns the metadata for the card with the given card ID, e.g.
+// "CS2_029", as used by tools like Hearthstone Deck Tracker.
+func (db *CardDB) CardByID(id string) (CardInfo, bool) {
+	dbfID, ok := db.byID[id]
+	if !ok {
+		return CardInfo{}, false
+	}
+	return db.cards[dbfID], true
+}
+
+// DBFID returns the DBF ID of the card with the given card ID, e.g. "EX1_001".
+func (db *CardDB) DBFID(cardID string) (uint64, bool) {
+	dbfID, ok := db.byID[cardID]
+	return dbfID, ok
+}
+
+// CardID returns the card ID of the card with the given DBF ID, e.g.
+// "EX1_001". It reports false if the card is unknown or has no card ID.
+func (db *CardDB) CardID(dbfID uint64) (string, bool) {
+	card, ok := db.cards[dbfID]
+	return card.ID, ok && card.ID != ""
+}
+
+// CardsByName returns the cards with the given name in any loaded locale,
+// compared case-insensitively, ordered by DBF ID. Reprints of a card share its
+// name, so several cards may be returned.
+func (db *CardDB) CardsByName(name string) []CardInfo {
+	var cards []CardInfo
+	for _, dbfID := range db.byName[strings.ToLower(name)] {
+		cards = append(cards, db.cards[dbfID])
+	}
+	return cards
+}
+
+// Len returns the number of cards in the database.
+func (db *CardDB) Len() int {
+	return len(db.cards)
+}
+
+// lookup returns the metadata for the card with the given DBF ID or an
+// UnknownCardError if the card is not in the database.
+func (db *CardDB) lookup(dbfID uint64) (CardInfo, error) {
+	card, ok := db.cards[dbfID]
+	if !ok {
+		return CardInfo{}, UnknownCardError{DBFID: dbfID}
+	}
+	return card, nil
+}
+
+// lookupID returns the DBF ID of the card with the given card ID or an
+// UnknownCardIDError if the card is not in the database.
+func (db *CardDB) lookupID(cardID string) (uint64, error) {
+	dbfID, ok := db.byID[cardID]
+	if !ok {
+		return 0, UnknownCardIDError{ID: cardID}
+	}
+	return dbfID, nil
+}
+
+// UnknownCardError is returned when a card is not found in a CardDB.
+type UnknownCardError struct {
+	DBFID uint64
+}
+
+func (e UnknownCardError) Error() string {
+	return fmt.Sprintf("unknown card: DBF ID %d", e.DBFID)
+}
+
+// UnknownCardIDError is returned when a card ID is not found in a CardDB.
+type UnknownCardIDError struct {
+	ID string
+}
+
+func (e UnknownCardIDError) Error() string {
+	return fmt.Sprintf("unknown card ID: %q", e.ID)
+}
+
+// hearthstoneJSONCard mirrors a card object in HearthstoneJSON's cards.json.
+type hearthstoneJSONCard struct {
+	DBFID       uint64          `json:"dbfId"`
+	ID          string          `json:"id"`
+	Name        localizedString `json:"name"`
+	Cost        int             `json:"cost"`
+	Rarity      Rarity          `json:"rarity"`
+	Set         string          `json:"set"`
+	Type        string          `json:"type"`
+	CardClass   string          `json:"cardClass"`
+	Classes     []string        `json:"classes"`
+	SpellSchool string          `json:"spellSchool"`
+	Race        string          `json:"race"`
+	Races       []string        `json:"races"`
+	Mechanics   []string        `json:"mechanics"`
+	Collectible bool            `json:"collectible"`
+}
+
+func (c hearthstoneJSONCard) cardInfo() CardInfo {
+	// Older data has a single race rather than a list of races.
+	races := c.Races
+	if len(races) == 0 && c.Race != "" {
+		races = []string{c.Race}
+	}
+
+	return CardInfo{
+		DBFID:       c.DBFID,
+		ID:          c.ID,
+		Name:        c.Name.name(),
+		Names:       c.Name.locales,
+		Cost:        c.Cost,
+		Rarity:      c.Rarity,
+		Set:         c.Set,
+		Type:        c.Type,
+		Class:       c.CardClass,
+		Classes:     c.Classes,
+		SpellSchool: c.SpellSchool,
+		Races:       races,
+		Mechanics:   c.Mechanics,
+		Collectible: c.Collectible,
+	}
+}
+
+// localizedString is a HearthstoneJSON string field, which is either a plain
+// string or, in the all-locales data, an object mapping locales to strings.
+type localizedString struct {
+	value   string
+	locales map[string]string
+}
+
+func (s *localizedString) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, &s.locales)
+	}
+	return json.Unmarshal(data, &s.value)
+}
+
+// name returns the plain string, or the English string for localized data.
+func (s localizedString) name() string {
+	if s.locales != nil {
+		return s.locales["enUS"]
+	}
+	return s.value
+}
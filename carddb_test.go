@@ -0,0 +1,140 @@
+package deckstrings_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCardDB(t *testing.T) *CardDB {
+	file, err := os.Open("testdata/cards.json")
+	require.Nil(t, err)
+	defer file.Close()
+
+	db, err := LoadCardDB(file)
+	require.Nil(t, err)
+	return db
+}
+
+func TestLoadCardDB(t *testing.T) {
+	db := testCardDB(t)
+	assert.Equal(t, 19, db.Len())
+
+	card, ok := db.Card(315)
+	assert.True(t, ok)
+	assert.Equal(t, CardInfo{
+		DBFID:       315,
+		ID:          "CS2_029",
+		Name:        "Fireball",
+		Cost:        4,
+		Rarity:      RarityFree,
+		Set:         "LEGACY",
+		Type:        "SPELL",
+		Class:       "MAGE",
+		SpellSchool: "FIRE",
+		Collectible: true,
+	}, card)
+
+	_, ok = db.Card(12345678)
+	assert.False(t, ok)
+}
+
+func TestLoadCardDBRaces(t *testing.T) {
+	db := testCardDB(t)
+
+	card, _ := db.Card(395)
+	assert.Equal(t, []string{"ELEMENTAL"}, card.Races)
+
+	card, _ = db.Card(581)
+	assert.Equal(t, []string{"DRAGON"}, card.Races)
+}
+
+func TestLoadCardDBInvalid(t *testing.T) {
+	_, err := LoadCardDB(strings.NewReader("{"))
+	assert.NotNil(t, err)
+}
+
+func TestNewCardDB(t *testing.T) {
+	db := NewCardDB([]CardInfo{{DBFID: 1, Name: "A"}, {DBFID: 1, Name: "B"}})
+	assert.Equal(t, 1, db.Len())
+
+	card, ok := db.Card(1)
+	assert.True(t, ok)
+	assert.Equal(t, "B", card.Name)
+}
+
+func TestCardByID(t *testing.T) {
+	db := testCardDB(t)
+
+	card, ok := db.CardByID("CS2_029")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(315), card.DBFID)
+
+	_, ok = db.CardByID("NOPE_001")
+	assert.False(t, ok)
+
+	_, ok = db.CardByID("")
+	assert.False(t, ok)
+}
+
+func TestCardsByName(t *testing.T) {
+	db := testCardDB(t)
+
+	cards := db.CardsByName("fireBALL")
+	if assert.Len(t, cards, 2) {
+		assert.Equal(t, uint64(315), cards[0].DBFID)
+		assert.Equal(t, uint64(64678), cards[1].DBFID)
+	}
+
+	assert.Empty(t, db.CardsByName("Leeroy Jenkins"))
+}
+
+func TestLoadCardDBLocalized(t *testing.T) {
+	file, err := os.Open("testdata/cards.all.json")
+	require.Nil(t, err)
+	defer file.Close()
+
+	db, err := LoadCardDB(file)
+	require.Nil(t, err)
+
+	card, ok := db.Card(315)
+	assert.True(t, ok)
+	assert.Equal(t, "Fireball", card.Name)
+	assert.Equal(t, "Feuerball", card.Names["deDE"])
+
+	for _, name := range []string{"Fireball", "feuerball", "BOULE DE FEU"} {
+		cards := db.CardsByName(name)
+		if assert.Len(t, cards, 1, name) {
+			assert.Equal(t, uint64(315), cards[0].DBFID)
+		}
+	}
+
+	// Names shared across locales are indexed once.
+	assert.Len(t, db.CardsByName("Alexstrasza"), 1)
+}
+
+func TestCardIDMapping(t *testing.T) {
+	db := testCardDB(t)
+
+	dbfID, ok := db.DBFID("EX1_561")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(581), dbfID)
+
+	id, ok := db.CardID(581)
+	assert.True(t, ok)
+	assert.Equal(t, "EX1_561", id)
+
+	_, ok = db.DBFID("NOPE_001")
+	assert.False(t, ok)
+
+	_, ok = db.CardID(999999)
+	assert.False(t, ok)
+
+	// Cards without a card ID can't be mapped.
+	_, ok = NewCardDB([]CardInfo{{DBFID: 1, Name: "A"}}).CardID(1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,45 @@
+package deckstrings
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// EncodeFromCardIDs encodes a deck given as card IDs, e.g. "HERO_08" and
+// "CS2_029", rather than DBF IDs, for tools like simulators and replay parsers
+// that only know card IDs. cards maps card IDs to their count in the deck.
+//
+// Returns an UnknownCardIDError if the hero or a card is not in db, and an
+// error if any count is less than 1.
+func EncodeFromCardIDs(format Format, heroCardID string, cards map[string]int, db *CardDB, opts ...EncodeOption) (deckstring string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring encode")
+		}
+	}()
+
+	hero, err := db.lookupID(heroCardID)
+	if err != nil {
+		return "", err
+	}
+
+	counts := make(map[uint64]uint64, len(cards))
+	for id, count := range cards {
+		if count < 1 {
+			return "", fmt.Errorf("invalid card count for card ID %q", id)
+		}
+		dbfID, err := db.lookupID(id)
+		if err != nil {
+			return "", err
+		}
+		counts[dbfID] += uint64(count)
+	}
+
+	var buf bytes.Buffer
+	if err := encode(&buf, FromCardsMap(format, []uint64{hero}, counts), newEncodeOptions(opts)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
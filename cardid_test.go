@@ -0,0 +1,34 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeFromCardIDs(t *testing.T) {
+	db := testCardDB(t)
+
+	deckstring, err := EncodeFromCardIDs(FormatStandard, "HERO_08", map[string]int{"CS2_029": 2, "EX1_561": 1}, db)
+	assert.Nil(t, err)
+	assert.Equal(t, MustEncode(Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{315, 2}, {581, 1}},
+	}), deckstring)
+}
+
+func TestEncodeFromCardIDsInvalid(t *testing.T) {
+	db := testCardDB(t)
+
+	_, err := EncodeFromCardIDs(FormatWild, "HERO_99", map[string]int{"CS2_029": 2}, db)
+	assert.Equal(t, UnknownCardIDError{ID: "HERO_99"}, errors.Cause(err))
+
+	_, err = EncodeFromCardIDs(FormatWild, "HERO_08", map[string]int{"NOPE_001": 2}, db)
+	assert.EqualError(t, err, `deckstring encode: unknown card ID: "NOPE_001"`)
+
+	_, err = EncodeFromCardIDs(FormatWild, "HERO_08", map[string]int{"CS2_029": 0}, db)
+	assert.EqualError(t, err, `deckstring encode: invalid card count for card ID "CS2_029"`)
+}
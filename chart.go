@@ -0,0 +1,112 @@
+package deckstrings
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// blocks are the Unicode block elements for bars filled to each eighth of a
+// row.
+var blocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// Chart renders the curve as a bar chart height rows tall drawn with Unicode
+// block elements, for bots and terminal tools. Each bucket's count is shown
+// above its bar and its cost below, with the last bucket labeled e.g. "7+".
+// Bars are scaled so the tallest fills the chart, and any nonzero count shows
+// at least a sliver. Columns widen to fit large counts and costs. For example,
+// with a height of 4:
+//
+//	   2  3  2  7  2     2
+//	           ██
+//	           ██
+//	  ▁▁ ▆▆ ▁▁ ██ ▁▁    ▁▁
+//	  ██ ██ ██ ██ ██    ██
+//	0  1  2  3  4  5  6 7+
+//
+// See ASCIIChart for a variant using only ASCII characters.
+func (c Curve) Chart(height int) string {
+	return c.chart(height, blocks)
+}
+
+// ASCIIChart is like Chart but draws bars with '#' characters in whole rows,
+// for environments without Unicode support.
+func (c Curve) ASCIIChart(height int) string {
+	return c.chart(height, []string{" ", "#"})
+}
+
+// chart renders the curve with levels, the strings drawn for a row filled to
+// each fraction of its height, from empty to full.
+func (c Curve) chart(height int, levels []string) string {
+	if height < 1 || len(c) == 0 {
+		return ""
+	}
+
+	var tallest uint64
+	for _, count := range c {
+		tallest = max(tallest, count)
+	}
+
+	// Bar heights in units of a fraction of a row.
+	steps := uint64(len(levels) - 1)
+	bars := make([]uint64, len(c))
+	for i, count := range c {
+		if tallest > 0 {
+			bars[i] = (count*uint64(height)*steps + tallest/2) / tallest
+		}
+		if count > 0 && bars[i] == 0 {
+			bars[i] = 1
+		}
+	}
+
+	counts := make([]string, len(c))
+	labels := make([]string, len(c))
+	for i, count := range c {
+		if count > 0 {
+			counts[i] = fmt.Sprint(count)
+		}
+		labels[i] = fmt.Sprint(i)
+		if i == len(c)-1 && i > 0 {
+			labels[i] += "+"
+		}
+	}
+
+	// Columns are wide enough for the widest count or label plus a space
+	// separating it from its neighbor, and at least 3 wide.
+	width := 3
+	for i := range c {
+		width = max(width, len(counts[i])+1, len(labels[i])+1)
+	}
+
+	var lines []string
+	line := func(cell func(i int) string) {
+		var b strings.Builder
+		for i := range c {
+			// Pad by rune since block elements are multibyte.
+			text := cell(i)
+			b.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(text)))
+			b.WriteString(text)
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	line(func(i int) string { return counts[i] })
+
+	for row := height - 1; row >= 0; row-- {
+		line(func(i int) string {
+			// Units of this bar falling within this row.
+			filled := int64(bars[i]) - int64(row)*int64(steps)
+			if filled <= 0 {
+				return ""
+			}
+			if filled > int64(steps) {
+				filled = int64(steps)
+			}
+			return strings.Repeat(levels[filled], 2)
+		})
+	}
+
+	line(func(i int) string { return labels[i] })
+
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,85 @@
+package deckstrings
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ChecksumError is returned when a deckstring's checksum does not match its
+// contents, meaning it was corrupted in transmission or copy/paste.
+type ChecksumError struct {
+	Expected uint32
+	Actual   uint32
+}
+
+func (e ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch: expected %08x, got %08x", e.Expected, e.Actual)
+}
+
+// EncodeChecksum encodes a deck like Encode and appends a checksum so that
+// corruption is detected on decode. Use DecodeChecksum to decode the result.
+//
+// The checksum is the CRC-32 (IEEE) of the deckstring, appended after a "."
+// as six unpadded base64 characters, e.g.
+// "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=.B/RSpg".
+// Checksummed deckstrings can't be imported into the game; use VerifyChecksum
+// to recover the plain deckstring.
+func EncodeChecksum(deck Deck, opts ...EncodeOption) (string, error) {
+	deckstring, err := Encode(deck, opts...)
+	if err != nil {
+		return "", err
+	}
+	return deckstring + "." + encodeChecksum(deckstring), nil
+}
+
+// DecodeChecksum verifies the checksum of a deckstring created by
+// EncodeChecksum and decodes the deckstring like Decode. If the checksum does
+// not match, errors.Cause of the returned error is a ChecksumError.
+func DecodeChecksum(checksummed string, opts ...DecodeOption) (deck Deck, err error) {
+	deckstring, err := VerifyChecksum(checksummed)
+	if err != nil {
+		return Deck{}, err
+	}
+	return Decode(deckstring, opts...)
+}
+
+// VerifyChecksum verifies the checksum of a deckstring created by
+// EncodeChecksum and returns the deckstring with the checksum removed. The
+// deckstring itself is not decoded. If the checksum does not match,
+// errors.Cause of the returned error is a ChecksumError.
+func VerifyChecksum(checksummed string) (deckstring string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring checksum")
+		}
+	}()
+
+	i := strings.LastIndexByte(checksummed, '.')
+	if i < 0 {
+		return "", fmt.Errorf("missing checksum")
+	}
+
+	deckstring, suffix := checksummed[:i], checksummed[i+1:]
+	sum, err := base64.RawStdEncoding.DecodeString(suffix)
+	if err != nil || len(sum) != 4 {
+		return "", fmt.Errorf("invalid checksum: %q", suffix)
+	}
+
+	expected := binary.BigEndian.Uint32(sum)
+	if actual := crc32.ChecksumIEEE([]byte(deckstring)); actual != expected {
+		return "", ChecksumError{Expected: expected, Actual: actual}
+	}
+
+	return deckstring, nil
+}
+
+func encodeChecksum(deckstring string) string {
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc32.ChecksumIEEE([]byte(deckstring)))
+	return base64.RawStdEncoding.EncodeToString(sum[:])
+}
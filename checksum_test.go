@@ -0,0 +1,44 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeChecksum(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck := MustDecode(deckstring)
+
+	checksummed, err := EncodeChecksum(deck)
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring+".B/RSpg", checksummed)
+
+	decoded, err := DecodeChecksum(checksummed)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+
+	stripped, err := VerifyChecksum(checksummed)
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring, stripped)
+}
+
+func TestDecodeChecksumMismatch(t *testing.T) {
+	// A single corrupted character.
+	_, err := DecodeChecksum("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAE=.B/RSpg")
+	assert.EqualError(t, err, "deckstring checksum: checksum mismatch: expected 07f452a6, got 639897a2")
+	assert.IsType(t, ChecksumError{}, errors.Cause(err))
+}
+
+func TestDecodeChecksumInvalid(t *testing.T) {
+	_, err := DecodeChecksum("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.EqualError(t, err, "deckstring checksum: missing checksum")
+
+	_, err = DecodeChecksum("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=.B/RS")
+	assert.EqualError(t, err, `deckstring checksum: invalid checksum: "B/RS"`)
+
+	_, err = EncodeChecksum(Deck{Cards: [][2]uint64{{1, 0}}})
+	assert.EqualError(t, err, "deckstring encode: invalid card count for DBF ID 1")
+}
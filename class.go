@@ -0,0 +1,42 @@
+package deckstrings
+
+// classNames maps HearthstoneJSON class names to display names.
+var classNames = map[string]string{
+	"DEATHKNIGHT": "Death Knight",
+	"DEMONHUNTER": "Demon Hunter",
+	"DRUID":       "Druid",
+	"HUNTER":      "Hunter",
+	"MAGE":        "Mage",
+	"PALADIN":     "Paladin",
+	"PRIEST":      "Priest",
+	"ROGUE":       "Rogue",
+	"SHAMAN":      "Shaman",
+	"WARLOCK":     "Warlock",
+	"WARRIOR":     "Warrior",
+}
+
+// HeroClass returns the HearthstoneJSON class of the deck's first hero, e.g.
+// "MAGE". Heroes missing from db are looked up in DefaultHeroes. Returns "" if
+// the deck has no heroes or the class is unknown.
+func HeroClass(deck Deck, db *CardDB) string {
+	if len(deck.Heroes) == 0 {
+		return ""
+	}
+
+	hero := deck.Heroes[0]
+	if info, ok := db.Card(hero); ok {
+		return info.Class
+	}
+	for class, id := range DefaultHeroes {
+		if id == hero {
+			return class
+		}
+	}
+	return ""
+}
+
+// ClassName returns the display name of the class of the deck's first hero,
+// e.g. "Death Knight", or "" if it is unknown. See HeroClass.
+func ClassName(deck Deck, db *CardDB) string {
+	return classNames[HeroClass(deck, db)]
+}
@@ -0,0 +1,24 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassName(t *testing.T) {
+	db := testCardDB(t)
+
+	deck := Deck{Heroes: []uint64{637}}
+	assert.Equal(t, "MAGE", HeroClass(deck, db))
+	assert.Equal(t, "Mage", ClassName(deck, db))
+
+	// Heroes missing from the database fall back to the default heroes.
+	deck = Deck{Heroes: []uint64{56550}}
+	assert.Equal(t, "DEMONHUNTER", HeroClass(deck, db))
+	assert.Equal(t, "Demon Hunter", ClassName(deck, db))
+
+	assert.Equal(t, "", ClassName(Deck{Heroes: []uint64{1}}, db))
+	assert.Equal(t, "", ClassName(Deck{}, db))
+}
@@ -0,0 +1,216 @@
+// Package cluster groups Hearthstone decks by card overlap, e.g. to discover
+// archetypes in a large collection of decoded deckstrings.
+//
+// Decks are compared with deckstrings.Similarity, and the distance between two
+// decks is 1 minus their similarity. Options passed to the clustering functions
+// are forwarded to deckstrings.Similarity.
+package cluster
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/schmich/deckstrings"
+)
+
+// Result is the outcome of clustering a list of decks.
+//
+// Assignments holds the cluster index of each deck, in input order. Centroids
+// holds, for each cluster, the index of its medoid: the member deck with the
+// smallest total distance to the other members of the cluster.
+type Result struct {
+	Assignments []int
+	Centroids   []int
+}
+
+// Members returns the indices of the decks assigned to the given cluster.
+func (r Result) Members(cluster int) []int {
+	var members []int
+	for i, assignment := range r.Assignments {
+		if assignment == cluster {
+			members = append(members, i)
+		}
+	}
+	return members
+}
+
+// Greedy clusters decks in a single pass: each deck joins the first cluster
+// whose leader (the deck that started the cluster) has a similarity of at least
+// threshold with it, or else starts a new cluster. Centroids are the medoids of
+// the resulting clusters.
+//
+// Greedy is fast and needs no cluster count up front, but its result depends
+// on the order of the decks.
+func Greedy(decks []deckstrings.Deck, threshold float64, opts ...deckstrings.SimilarityOption) Result {
+	result := Result{Assignments: make([]int, len(decks))}
+
+	var leaders []int
+	for i, deck := range decks {
+		assigned := false
+		for cluster, leader := range leaders {
+			if deckstrings.Similarity(deck, decks[leader], opts...) >= threshold {
+				result.Assignments[i] = cluster
+				assigned = true
+				break
+			}
+		}
+
+		if !assigned {
+			result.Assignments[i] = len(leaders)
+			leaders = append(leaders, i)
+		}
+	}
+
+	result.Centroids = make([]int, len(leaders))
+	for cluster := range leaders {
+		result.Centroids[cluster] = medoid(decks, result.Members(cluster), opts)
+	}
+
+	return result
+}
+
+// KMedoids partitions decks into k clusters around medoids. Initial medoids
+// are chosen with rng, favoring decks far from medoids already chosen. Decks
+// are assigned to their nearest medoid. Each iteration then moves each medoid
+// to the member minimizing the total distance within its cluster and reassigns
+// the decks, until the assignments stop changing or maxIterations is reached.
+// The returned assignments are always those of the returned medoids.
+//
+// Each iteration computes distances between members of the same cluster, so
+// the cost grows with the square of the cluster sizes.
+//
+// Returns an error if k is less than 1 or greater than the number of decks, if
+// rng is nil, or if maxIterations is less than 1.
+func KMedoids(decks []deckstrings.Deck, k int, rng *rand.Rand, maxIterations int, opts ...deckstrings.SimilarityOption) (Result, error) {
+	if k < 1 || k > len(decks) {
+		return Result{}, fmt.Errorf("cluster: invalid cluster count %d for %d decks", k, len(decks))
+	}
+	if rng == nil {
+		return Result{}, fmt.Errorf("cluster: nil rng")
+	}
+	if maxIterations < 1 {
+		return Result{}, fmt.Errorf("cluster: invalid iteration count %d", maxIterations)
+	}
+
+	distance := func(i, j int) float64 {
+		return 1 - deckstrings.Similarity(decks[i], decks[j], opts...)
+	}
+
+	centroids := initialMedoids(len(decks), k, rng, distance)
+	result := Result{Assignments: make([]int, len(decks)), Centroids: centroids}
+
+	// assign moves each deck to its nearest medoid, reporting whether any
+	// assignment changed.
+	assign := func() bool {
+		changed := false
+		for i := range decks {
+			if nearest := nearestMedoid(i, centroids, distance); result.Assignments[i] != nearest {
+				result.Assignments[i] = nearest
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	assign()
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		for cluster := range centroids {
+			if members := result.Members(cluster); len(members) > 0 {
+				centroids[cluster] = medoid(decks, members, opts)
+			}
+		}
+
+		if !assign() {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// nearestMedoid returns the cluster whose medoid is nearest to deck i. A
+// medoid always belongs to its own cluster, even if it duplicates another.
+func nearestMedoid(i int, medoids []int, distance func(i, j int) float64) int {
+	nearest := 0
+	for cluster, medoid := range medoids {
+		if medoid == i {
+			return cluster
+		}
+		if distance(i, medoid) < distance(i, medoids[nearest]) {
+			nearest = cluster
+		}
+	}
+	return nearest
+}
+
+// initialMedoids picks k distinct starting medoids, choosing each after the
+// first with probability proportional to its distance from the nearest medoid
+// already chosen.
+func initialMedoids(n, k int, rng *rand.Rand, distance func(i, j int) float64) []int {
+	medoids := []int{rng.Intn(n)}
+	chosen := map[int]bool{medoids[0]: true}
+
+	for len(medoids) < k {
+		weights := make([]float64, n)
+		total := 0.0
+		for i := range weights {
+			if chosen[i] {
+				continue
+			}
+
+			nearest := distance(i, medoids[0])
+			for _, medoid := range medoids[1:] {
+				if d := distance(i, medoid); d < nearest {
+					nearest = d
+				}
+			}
+
+			weights[i] = nearest
+			total += nearest
+		}
+
+		next := -1
+		if total > 0 {
+			target := rng.Float64() * total
+			for i, weight := range weights {
+				if weight == 0 {
+					continue
+				}
+				next = i
+				if target -= weight; target < 0 {
+					break
+				}
+			}
+		} else {
+			// Every remaining deck duplicates a medoid; pick any of them.
+			for i := 0; i < n && next < 0; i++ {
+				if !chosen[i] {
+					next = i
+				}
+			}
+		}
+
+		medoids = append(medoids, next)
+		chosen[next] = true
+	}
+
+	return medoids
+}
+
+// medoid returns the member with the smallest total distance to the others.
+func medoid(decks []deckstrings.Deck, members []int, opts []deckstrings.SimilarityOption) int {
+	best, bestTotal := members[0], -1.0
+	for _, i := range members {
+		total := 0.0
+		for _, j := range members {
+			if i != j {
+				total += 1 - deckstrings.Similarity(decks[i], decks[j], opts...)
+			}
+		}
+
+		if bestTotal < 0 || total < bestTotal {
+			best, bestTotal = i, total
+		}
+	}
+	return best
+}
@@ -0,0 +1,86 @@
+package cluster_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDecks() []deckstrings.Deck {
+	return []deckstrings.Deck{
+		{Cards: [][2]uint64{{1, 2}, {2, 2}, {3, 2}}},
+		{Cards: [][2]uint64{{10, 2}, {11, 2}, {12, 2}}},
+		{Cards: [][2]uint64{{1, 2}, {2, 2}, {3, 1}}},
+		{Cards: [][2]uint64{{10, 2}, {11, 2}, {13, 2}}},
+		{Cards: [][2]uint64{{1, 2}, {2, 2}, {4, 2}}},
+	}
+}
+
+func TestGreedy(t *testing.T) {
+	result := cluster.Greedy(testDecks(), 0.5)
+	assert.Equal(t, []int{0, 1, 0, 1, 0}, result.Assignments)
+	assert.Equal(t, []int{2, 1}, result.Centroids)
+	assert.Equal(t, []int{0, 2, 4}, result.Members(0))
+	assert.Equal(t, []int{1, 3}, result.Members(1))
+}
+
+func TestGreedyThreshold(t *testing.T) {
+	result := cluster.Greedy(testDecks(), 1)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, result.Assignments)
+
+	result = cluster.Greedy(testDecks(), 0)
+	assert.Equal(t, []int{0, 0, 0, 0, 0}, result.Assignments)
+}
+
+func TestKMedoids(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		result, err := cluster.KMedoids(testDecks(), 2, rand.New(rand.NewSource(seed)), 10)
+		assert.Nil(t, err)
+
+		a, b := result.Assignments[0], result.Assignments[1]
+		assert.NotEqual(t, a, b)
+		assert.Equal(t, []int{a, b, a, b, a}, result.Assignments)
+		assert.Equal(t, 2, result.Centroids[a])
+	}
+}
+
+func TestKMedoidsDuplicates(t *testing.T) {
+	deck := deckstrings.Deck{Cards: [][2]uint64{{1, 1}}}
+	decks := []deckstrings.Deck{deck, deck, deck}
+
+	result, err := cluster.KMedoids(decks, 3, rand.New(rand.NewSource(1)), 10)
+	assert.Nil(t, err)
+	assert.Len(t, result.Centroids, 3)
+	assert.ElementsMatch(t, []int{0, 1, 2}, result.Centroids)
+}
+
+func TestKMedoidsInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	_, err := cluster.KMedoids(testDecks(), 0, rng, 10)
+	assert.NotNil(t, err)
+
+	_, err = cluster.KMedoids(testDecks(), 6, rng, 10)
+	assert.NotNil(t, err)
+
+	_, err = cluster.KMedoids(testDecks(), 2, rng, 0)
+	assert.EqualError(t, err, "cluster: invalid iteration count 0")
+
+	_, err = cluster.KMedoids(testDecks(), 2, nil, 10)
+	assert.EqualError(t, err, "cluster: nil rng")
+}
+
+func TestKMedoidsSingleIteration(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		result, err := cluster.KMedoids(testDecks(), 2, rand.New(rand.NewSource(seed)), 1)
+		assert.Nil(t, err)
+
+		// Each medoid belongs to its own cluster after the last update.
+		for c, medoid := range result.Centroids {
+			assert.Equal(t, c, result.Assignments[medoid])
+		}
+	}
+}
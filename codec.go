@@ -0,0 +1,148 @@
+package deckstrings
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Codec encodes and decodes decks for a single deckstring version, letting
+// versions unknown to this package be supported with RegisterCodec. The
+// reserved and version fields at the start of every payload are handled by
+// this package; a Codec reads and writes only the fields that follow them.
+//
+// Decode options other than MaxPayloadLength and encode options other than
+// EncodeVersion and Compress apply only to the built-in version.
+type Codec interface {
+	// EncodeDeck writes the fields of deck following the version.
+	EncodeDeck(writer io.Writer, deck Deck) error
+
+	// DecodeDeck reads a deck from the fields following the version.
+	DecodeDeck(reader io.ByteReader) (Deck, error)
+}
+
+// codec is implemented by the codecs in the registry. Unlike Codec, it
+// handles the whole payload, including the reserved and version fields.
+type codec interface {
+	encode(writer io.Writer, deck Deck, options *encodeOptions) error
+	decode(reader io.ByteReader, options *decodeOptions) (Deck, DecodeInfo, error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[uint64]codec{
+		Version: versionOneCodec{},
+	}
+)
+
+// RegisterCodec makes codec available for encoding and decoding deckstrings
+// with the given version. Decode uses it for deckstrings with that version and
+// Encode uses it with EncodeVersion. It panics if codec is nil or a codec is
+// already registered for the version, including the built-in Version.
+func RegisterCodec(version uint64, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	if codec == nil {
+		panic("deckstrings: RegisterCodec codec is nil")
+	}
+	if _, dup := codecs[version]; dup {
+		panic(fmt.Sprintf("deckstrings: RegisterCodec called twice for version %d", version))
+	}
+	codecs[version] = externalCodec{version: version, codec: codec}
+}
+
+func lookupCodec(version uint64) (codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	c, ok := codecs[version]
+	return c, ok
+}
+
+// peekVersion reads the version of a payload, returning a reader over the
+// whole payload. The version is 0 if the payload is too short.
+func peekVersion(reader io.ByteReader) (io.ByteReader, uint64) {
+	recording := &recordingReader{reader: reader}
+	varint := &varintReader{reader: recording}
+
+	var version uint64
+	if _, err := varint.Read(); err == nil {
+		version, _ = varint.Read()
+	}
+
+	return &prefixedReader{prefix: recording.bytes, reader: reader}, version
+}
+
+// versionOneCodec is the built-in codec for version 1 deckstrings.
+type versionOneCodec struct{}
+
+func (versionOneCodec) encode(writer io.Writer, deck Deck, options *encodeOptions) error {
+	return encodeVersionOne(writer, deck, options)
+}
+
+func (versionOneCodec) decode(reader io.ByteReader, options *decodeOptions) (Deck, DecodeInfo, error) {
+	return decodeVersionOne(reader, options)
+}
+
+// externalCodec adapts a Codec registered with RegisterCodec.
+type externalCodec struct {
+	version uint64
+	codec   Codec
+}
+
+func (c externalCodec) encode(writer io.Writer, deck Deck, options *encodeOptions) error {
+	// Buffer the payload so nothing is written if the deck can't be encoded.
+	var buf bytes.Buffer
+	(&varintWriter{&buf}).WriteMany([]uint64{options.reserved, c.version})
+	if err := c.codec.EncodeDeck(&buf, deck); err != nil {
+		return err
+	}
+
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+func (c externalCodec) decode(reader io.ByteReader, options *decodeOptions) (Deck, DecodeInfo, error) {
+	limited := &limitedReader{reader: reader, limit: options.maxPayloadLength}
+	varint := &varintReader{reader: limited}
+
+	// The version was already read by peekVersion.
+	var header [2]uint64
+	for i := range header {
+		value, err := varint.Read()
+		if err != nil {
+			return Deck{}, DecodeInfo{}, err
+		}
+		header[i] = value
+	}
+
+	if header[0] != 0 && !options.anyReserved {
+		return Deck{}, DecodeInfo{}, fmt.Errorf("unexpected reserved byte: %d", header[0])
+	}
+
+	deck, err := c.codec.DecodeDeck(varint)
+	if err == nil {
+		trailing := readRemaining(varint)
+		if limited.exceeded {
+			err = limited.err()
+		}
+		info := DecodeInfo{
+			Reserved:      header[0],
+			Version:       c.version,
+			PayloadLength: varint.offset,
+			TrailingBytes: len(trailing),
+			Trailing:      trailing,
+		}
+		if err == nil {
+			deck.Version = c.version
+			return deck, info, nil
+		}
+	}
+
+	if limited.exceeded {
+		err = limited.err()
+	}
+	return Deck{}, DecodeInfo{}, err
+}
@@ -0,0 +1,107 @@
+package deckstrings_test
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+// pairsCodec encodes a deck as its format, hero, and card count followed by
+// the heroes and card pairs.
+type pairsCodec struct{}
+
+func (pairsCodec) EncodeDeck(writer io.Writer, deck Deck) error {
+	values := []uint64{uint64(deck.Format), uint64(len(deck.Heroes)), uint64(len(deck.Cards))}
+	values = append(values, deck.Heroes...)
+	for _, card := range deck.Cards {
+		values = append(values, card[0], card[1])
+	}
+
+	var buf []byte
+	for _, value := range values {
+		buf = binary.AppendUvarint(buf, value)
+	}
+	_, err := writer.Write(buf)
+	return err
+}
+
+func (pairsCodec) DecodeDeck(reader io.ByteReader) (Deck, error) {
+	var header [3]uint64
+	for i := range header {
+		value, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return Deck{}, err
+		}
+		header[i] = value
+	}
+
+	deck := Deck{Format: Format(header[0]), Heroes: []uint64{}, Cards: [][2]uint64{}}
+	for i := uint64(0); i < header[1]; i++ {
+		hero, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return Deck{}, err
+		}
+		deck.Heroes = append(deck.Heroes, hero)
+	}
+	for i := uint64(0); i < header[2]; i++ {
+		var card [2]uint64
+		for j := range card {
+			value, err := binary.ReadUvarint(reader)
+			if err != nil {
+				return Deck{}, err
+			}
+			card[j] = value
+		}
+		deck.Cards = append(deck.Cards, card)
+	}
+
+	return deck, nil
+}
+
+func init() {
+	RegisterCodec(99, pairsCodec{})
+}
+
+func TestRegisterCodec(t *testing.T) {
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+
+	deckstring, err := Encode(deck, EncodeVersion(99))
+	assert.Nil(t, err)
+	assert.NotEqual(t, MustEncode(deck), deckstring)
+
+	decoded, info, err := DecodeWithInfo(deckstring)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(99), info.Version)
+	assert.Equal(t, uint64(99), decoded.Version)
+	decoded.Version = 0
+	assert.Equal(t, deck, decoded)
+
+	// Compression wraps any version.
+	decoded, err = Decode(MustEncode(deck, EncodeVersion(99), Compress()))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(99), decoded.Version)
+
+	_, err = Decode(deckstring, MaxPayloadLength(10))
+	assert.EqualError(t, err, "deckstring decode: payload exceeds limit of 10 bytes")
+
+	_, err = Decode("AGMCAQ==")
+	assert.EqualError(t, err, "deckstring decode: EOF")
+}
+
+func TestRegisterCodecInvalid(t *testing.T) {
+	assert.PanicsWithValue(t, "deckstrings: RegisterCodec called twice for version 1", func() {
+		RegisterCodec(Version, pairsCodec{})
+	})
+	assert.PanicsWithValue(t, "deckstrings: RegisterCodec called twice for version 99", func() {
+		RegisterCodec(99, pairsCodec{})
+	})
+	assert.PanicsWithValue(t, "deckstrings: RegisterCodec codec is nil", func() {
+		RegisterCodec(100, nil)
+	})
+
+	_, err := Encode(Deck{}, EncodeVersion(100))
+	assert.EqualError(t, err, "deckstring encode: unsupported version: 100")
+}
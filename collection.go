@@ -0,0 +1,269 @@
+package deckstrings
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Collection maps the DBF IDs of cards a player owns to the number of copies
+// owned.
+type Collection map[uint64]uint64
+
+// MissingCard is a card in a deck that a collection lacks copies of. Dust is
+// the arcane dust needed to craft the missing copies.
+type MissingCard struct {
+	DBFID   uint64
+	Missing uint64
+	Dust    uint64
+}
+
+// MissingReport lists the cards a collection lacks to build a deck, ordered
+// by DBF ID, along with the total number of missing copies and the total dust
+// needed to craft them.
+type MissingReport struct {
+	Cards  []MissingCard
+	Copies uint64
+	Dust   uint64
+}
+
+// Complete reports whether the collection has every card in the deck.
+func (r MissingReport) Complete() bool {
+	return len(r.Cards) == 0
+}
+
+// Missing reports which cards in the deck the collection lacks and the dust
+// needed to craft them using the costs in table, typically CraftingCost. Cards
+// from the Core set are free to all players, so they are never missing.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func (c Collection) Missing(deck Deck, db *CardDB, table DustTable) (MissingReport, error) {
+	var report MissingReport
+	counts := cardCountsByID(deck.Cards)
+	for _, dbfID := range sortedKeys(counts) {
+		card, err := db.lookup(dbfID)
+		if err != nil {
+			return MissingReport{}, err
+		}
+
+		owned := c[dbfID]
+		if card.Set == "CORE" || owned >= counts[dbfID] {
+			continue
+		}
+
+		missing := counts[dbfID] - owned
+		dust := missing * craftingCost(card, table)
+		report.Cards = append(report.Cards, MissingCard{DBFID: dbfID, Missing: missing, Dust: dust})
+		report.Copies += missing
+		report.Dust += dust
+	}
+	return report, nil
+}
+
+// ReadCollectionJSON reads a collection exported as JSON by Hearthstone Deck
+// Tracker or HSReplay.net. Cards are keyed by DBF ID or card ID, e.g. "315" or
+// "CS2_029". Two layouts are accepted: an object mapping cards to counts, as
+// in HSReplay.net's {"collection": {"315": [2, 1]}}, where an array lists the
+// copies owned of each finish (normal, golden, and so on); or an array of
+// objects with an "id" or "dbfId" and a "count" and optional "golden" count:
+//
+//	[{"id": "CS2_029", "count": 2, "golden": 1}]
+//
+// Copies of every finish are summed since any of them can be put in a deck.
+// Returns an UnknownCardIDError if a card ID is not in db.
+func ReadCollectionJSON(reader io.Reader, db *CardDB) (collection Collection, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "collection read")
+		}
+	}()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	collection = make(Collection)
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		var entries []struct {
+			ID     string `json:"id"`
+			CardID string `json:"cardId"`
+			DBFID  uint64 `json:"dbfId"`
+			Count  uint64 `json:"count"`
+			Golden uint64 `json:"golden"`
+		}
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			dbfID := entry.DBFID
+			if id := firstNonEmpty(entry.ID, entry.CardID); id != "" {
+				if dbfID, err = db.lookupID(id); err != nil {
+					return nil, err
+				}
+			} else if dbfID == 0 {
+				return nil, fmt.Errorf("collection entry without card ID")
+			}
+			collection[dbfID] += entry.Count + entry.Golden
+		}
+		return collection, nil
+	}
+
+	var export struct {
+		Collection map[string]json.RawMessage `json:"collection"`
+	}
+	if err := json.Unmarshal(raw, &export); err != nil {
+		return nil, err
+	}
+	cards := export.Collection
+	if cards == nil {
+		if err := json.Unmarshal(raw, &cards); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, value := range cards {
+		dbfID, err := collectionCardKey(key, db)
+		if err != nil {
+			return nil, err
+		}
+
+		var count uint64
+		if err := json.Unmarshal(value, &count); err != nil {
+			var finishes []uint64
+			if err := json.Unmarshal(value, &finishes); err != nil {
+				return nil, fmt.Errorf("invalid count for card %s: %s", key, value)
+			}
+			for _, n := range finishes {
+				count += n
+			}
+		}
+		collection[dbfID] += count
+	}
+	return collection, nil
+}
+
+// ReadCollectionCSV reads a collection exported as CSV, e.g. by Hearthstone
+// Deck Tracker. Columns are located by the names in the header row, compared
+// case-insensitively, and may appear in any order. Each row's card is
+// identified by an "id" or "cardid" column holding a card ID, a "dbfid" or
+// "dbf_id" column, or failing those a "name" column resolved with
+// CardDB.CardsByName. Copies are read from a "count" or "normal" column and an
+// optional "golden" column, which are summed.
+//
+// Returns an error if a row's card cannot be resolved or has an invalid count.
+func ReadCollectionCSV(reader io.Reader, db *CardDB) (collection Collection, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "collection read")
+		}
+	}()
+
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	column := func(names ...string) (int, bool) {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	idColumn, hasID := column("id", "cardid", "card_id")
+	dbfIDColumn, hasDBFID := column("dbfid", "dbf_id")
+	nameColumn, hasName := column("name")
+	if !hasID && !hasDBFID && !hasName {
+		return nil, fmt.Errorf("missing column: id, dbf_id, or name")
+	}
+	countColumn, hasCount := column("count", "normal")
+	if !hasCount {
+		return nil, fmt.Errorf("missing column: count")
+	}
+	goldenColumn, hasGolden := column("golden")
+
+	collection = make(Collection)
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		field := func(i int) string {
+			if i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		var dbfID uint64
+		switch {
+		case hasID && field(idColumn) != "":
+			if dbfID, err = db.lookupID(field(idColumn)); err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+		case hasDBFID && field(dbfIDColumn) != "":
+			if dbfID, err = strconv.ParseUint(field(dbfIDColumn), 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid dbf_id: %s", line, field(dbfIDColumn))
+			}
+		case hasName && field(nameColumn) != "":
+			card, ok := resolveCardName(db, field(nameColumn), -1)
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown card: %s", line, field(nameColumn))
+			}
+			dbfID = card.DBFID
+		default:
+			return nil, fmt.Errorf("line %d: missing card", line)
+		}
+
+		count, err := parseCollectionCount(field(countColumn))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid count: %s", line, field(countColumn))
+		}
+		if hasGolden {
+			golden, err := parseCollectionCount(field(goldenColumn))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid golden count: %s", line, field(goldenColumn))
+			}
+			count += golden
+		}
+
+		collection[dbfID] += count
+	}
+	return collection, nil
+}
+
+// collectionCardKey resolves a collection key, either a DBF ID or a card ID.
+func collectionCardKey(key string, db *CardDB) (uint64, error) {
+	if dbfID, err := strconv.ParseUint(key, 10, 64); err == nil {
+		return dbfID, nil
+	}
+	return db.lookupID(key)
+}
+
+// parseCollectionCount parses a count of copies, treating an empty field as 0.
+func parseCollectionCount(field string) (uint64, error) {
+	if field == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(field, 10, 64)
+}
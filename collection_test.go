@@ -0,0 +1,96 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionMissing(t *testing.T) {
+	db := testCardDB(t)
+	deck := Deck{Cards: [][2]uint64{{315, 2}, {581, 1}, {64678, 2}, {90749, 2}, {102983, 1}}}
+	collection := Collection{315: 2, 90749: 1, 102983: 3}
+
+	report, err := collection.Missing(deck, db, CraftingCost)
+	assert.Nil(t, err)
+	assert.Equal(t, MissingReport{
+		Cards: []MissingCard{
+			{DBFID: 581, Missing: 1, Dust: 1600},
+			{DBFID: 90749, Missing: 1, Dust: 100},
+		},
+		Copies: 2,
+		Dust:   1700,
+	}, report)
+	assert.False(t, report.Complete())
+
+	report, err = Collection{315: 2, 581: 1, 90749: 2, 102983: 1}.Missing(deck, db, CraftingCost)
+	assert.Nil(t, err)
+	assert.True(t, report.Complete())
+
+	_, err = collection.Missing(Deck{Cards: [][2]uint64{{999999, 1}}}, db, CraftingCost)
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestReadCollectionJSON(t *testing.T) {
+	db := testCardDB(t)
+
+	for _, text := range []string{
+		`{"collection": {"315": [1, 1], "CORE_CS2_029": [0, 2, 0, 0], "581": [1]}}`,
+		`{"315": 2, "64678": 2, "EX1_561": 1}`,
+		`[{"id": "CS2_029", "count": 1, "golden": 1}, {"cardId": "CORE_CS2_029", "golden": 2}, {"dbfId": 581, "count": 1}]`,
+	} {
+		collection, err := ReadCollectionJSON(strings.NewReader(text), db)
+		assert.Nil(t, err, text)
+		assert.Equal(t, Collection{315: 2, 581: 1, 64678: 2}, collection, text)
+	}
+}
+
+func TestReadCollectionJSONInvalid(t *testing.T) {
+	db := testCardDB(t)
+
+	tests := map[string]string{
+		`{"NOPE_001": 1}`:      `collection read: unknown card ID: "NOPE_001"`,
+		`{"315": "two"}`:       `collection read: invalid count for card 315: "two"`,
+		`[{"count": 1}]`:       `collection read: collection entry without card ID`,
+		`[{"id": "NOPE_001"}]`: `collection read: unknown card ID: "NOPE_001"`,
+		`{`:                    `collection read: unexpected EOF`,
+	}
+	for text, message := range tests {
+		_, err := ReadCollectionJSON(strings.NewReader(text), db)
+		assert.EqualError(t, err, message, text)
+	}
+}
+
+func TestReadCollectionCSV(t *testing.T) {
+	db := testCardDB(t)
+
+	text := "Name,Id,DbfId,Normal,Golden\n" +
+		"Fireball,CS2_029,,1,1\n" +
+		"Fireball,,64678,0,2\n" +
+		"alexstrasza,,,1,\n"
+
+	collection, err := ReadCollectionCSV(strings.NewReader(text), db)
+	assert.Nil(t, err)
+	assert.Equal(t, Collection{315: 2, 581: 1, 64678: 2}, collection)
+}
+
+func TestReadCollectionCSVInvalid(t *testing.T) {
+	db := testCardDB(t)
+
+	tests := map[string]string{
+		"count\n1\n":             `collection read: missing column: id, dbf_id, or name`,
+		"id\nCS2_029\n":          `collection read: missing column: count`,
+		"id,count\nNOPE_001,1\n": `collection read: line 2: unknown card ID: "NOPE_001"`,
+		"name,count\nLeeroy,1\n": `collection read: line 2: unknown card: Leeroy`,
+		"dbf_id,count\nx,1\n":    `collection read: line 2: invalid dbf_id: x`,
+		"id,count\nCS2_029,-1\n": `collection read: line 2: invalid count: -1`,
+		"id,count\n,1\n":         `collection read: line 2: missing card`,
+		"":                       `collection read: EOF`,
+	}
+	for text, message := range tests {
+		_, err := ReadCollectionCSV(strings.NewReader(text), db)
+		assert.EqualError(t, err, message, text)
+	}
+}
@@ -0,0 +1,108 @@
+package deckstrings
+
+import (
+	"cmp"
+	"sort"
+)
+
+// CardComparator compares two cards for sorting, returning a negative number
+// if a sorts before b, a positive number if a sorts after b, and 0 if they
+// are equal.
+type CardComparator func(a, b DeckCard) int
+
+var rarityOrder = map[Rarity]int{
+	RarityFree:      0,
+	RarityCommon:    1,
+	RarityRare:      2,
+	RarityEpic:      3,
+	RarityLegendary: 4,
+}
+
+var (
+	// ByCost orders cards by mana cost ascending.
+	ByCost CardComparator = func(a, b DeckCard) int {
+		return cmp.Compare(a.Cost, b.Cost)
+	}
+
+	// ByName orders cards by name.
+	ByName CardComparator = func(a, b DeckCard) int {
+		return cmp.Compare(a.Name, b.Name)
+	}
+
+	// ByRarity orders cards from free to legendary. Unknown rarities sort
+	// first.
+	ByRarity CardComparator = func(a, b DeckCard) int {
+		return cmp.Compare(rarityRank(a.Rarity), rarityRank(b.Rarity))
+	}
+
+	// BySet orders cards by the release of their set according to
+	// DefaultSetReleases, oldest first, then by set code. Sets without a known
+	// release, such as the Core set, sort last.
+	BySet CardComparator = func(a, b DeckCard) int {
+		releaseA, okA := DefaultSetReleases[a.Set]
+		releaseB, okB := DefaultSetReleases[b.Set]
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		}
+		if c := releaseA.Released.Compare(releaseB.Released); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Set, b.Set)
+	}
+
+	// ByCount orders cards by their count in the deck ascending.
+	ByCount CardComparator = func(a, b DeckCard) int {
+		return cmp.Compare(a.Count, b.Count)
+	}
+
+	// GameOrder orders cards as the game client lists them. See
+	// CompareGameOrder.
+	GameOrder CardComparator = func(a, b DeckCard) int {
+		return CompareGameOrder(a.CardInfo, b.CardInfo)
+	}
+)
+
+func rarityRank(rarity Rarity) int {
+	if rank, ok := rarityOrder[rarity]; ok {
+		return rank
+	}
+	return -1
+}
+
+// Descending reverses the order of a comparator.
+func Descending(comparator CardComparator) CardComparator {
+	return func(a, b DeckCard) int {
+		return comparator(b, a)
+	}
+}
+
+// Then combines comparators, ordering by each in turn until one
+// distinguishes the cards.
+func Then(comparators ...CardComparator) CardComparator {
+	return func(a, b DeckCard) int {
+		for _, comparator := range comparators {
+			if c := comparator(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// SortCards sorts cards by the given comparators in turn, e.g.
+//
+//	SortCards(cards, Descending(ByRarity), ByName)
+//
+// Cards the comparators consider equal are kept in game order, so the result
+// is deterministic. See GameOrder.
+func SortCards(cards []DeckCard, comparators ...CardComparator) {
+	// Copy so appending doesn't write into spare capacity of the caller's
+	// slice.
+	compare := Then(append(append([]CardComparator(nil), comparators...), GameOrder)...)
+	sort.Slice(cards, func(i, j int) bool {
+		return compare(cards[i], cards[j]) < 0
+	})
+}
@@ -0,0 +1,64 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sortedNames(t *testing.T, comparators ...CardComparator) []string {
+	deck := Deck{Cards: [][2]uint64{{77, 2}, {315, 2}, {581, 1}, {662, 2}, {40408, 1}, {64678, 1}, {102983, 1}}}
+	cards, err := Enrich(deck, testCardDB(t))
+	require.Nil(t, err)
+
+	SortCards(cards, comparators...)
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.Name
+	}
+	return names
+}
+
+func TestSortCards(t *testing.T) {
+	assert.Equal(t,
+		[]string{"Frostbolt", "Tyr's Tears", "Fireball", "Fireball", "Kazakus", "Polymorph", "Alexstrasza"},
+		sortedNames(t))
+	assert.Equal(t,
+		[]string{"Alexstrasza", "Fireball", "Fireball", "Frostbolt", "Kazakus", "Polymorph", "Tyr's Tears"},
+		sortedNames(t, ByName))
+	assert.Equal(t,
+		[]string{"Alexstrasza", "Fireball", "Fireball", "Kazakus", "Polymorph", "Tyr's Tears", "Frostbolt"},
+		sortedNames(t, Descending(ByCost)))
+	assert.Equal(t,
+		[]string{"Kazakus", "Alexstrasza", "Tyr's Tears", "Fireball", "Frostbolt", "Fireball", "Polymorph"},
+		sortedNames(t, Descending(ByRarity)))
+	assert.Equal(t,
+		[]string{"Alexstrasza", "Frostbolt", "Fireball", "Polymorph", "Kazakus", "Tyr's Tears", "Fireball"},
+		sortedNames(t, BySet))
+	assert.Equal(t,
+		[]string{"Frostbolt", "Fireball", "Polymorph", "Tyr's Tears", "Fireball", "Kazakus", "Alexstrasza"},
+		sortedNames(t, Descending(ByCount)))
+}
+
+func TestThen(t *testing.T) {
+	a := DeckCard{CardInfo: CardInfo{Name: "A", Cost: 1}, Count: 2}
+	b := DeckCard{CardInfo: CardInfo{Name: "B", Cost: 1}, Count: 1}
+
+	assert.Equal(t, 0, Then()(a, b))
+	assert.Equal(t, -1, Then(ByCost, ByName)(a, b))
+	assert.Equal(t, 1, Then(ByCost, ByCount, ByName)(a, b))
+}
+
+func TestSortCardsComparatorsUnchanged(t *testing.T) {
+	comparators := make([]CardComparator, 1, 2)
+	comparators[0] = ByCost
+	sentinel := CardComparator(func(a, b DeckCard) int { return 0 })
+	spare := append(comparators, sentinel)
+
+	sortedNames(t, comparators...)
+	a := DeckCard{CardInfo: CardInfo{Name: "A"}}
+	b := DeckCard{CardInfo: CardInfo{Name: "B"}}
+	assert.Equal(t, 0, spare[1](a, b))
+}
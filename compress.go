@@ -0,0 +1,101 @@
+package deckstrings
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+)
+
+// The compressed deckstring version supported by this package.
+const CompressedVersion = 1
+
+// compress returns payload deflated in a compressed container, or payload
+// itself if compressing it would not make it shorter. The container is laid
+// out as:
+//
+//	magic     0xFF 'Z'
+//	version   CompressedVersion
+//	payload   the deckstring payload, deflated
+func compress(payload []byte) []byte {
+	var buf bytes.Buffer
+	writeContainerHeader(&buf, containerCompressed, CompressedVersion)
+
+	// Writes to a bytes.Buffer don't fail, and the level is valid.
+	writer, _ := flate.NewWriter(&buf, flate.BestCompression)
+	writer.Write(payload)
+	writer.Close()
+
+	if buf.Len() >= len(payload) {
+		return payload
+	}
+	return buf.Bytes()
+}
+
+// decompress returns a reader over the deckstring payload read from reader,
+// inflating it if it is in a compressed container. Other payloads are read
+// unchanged.
+func decompress(reader io.ByteReader) (io.ByteReader, error) {
+	var prefix []byte
+	for _, expected := range []byte{containerMagic, containerCompressed} {
+		b, err := reader.ReadByte()
+		if err != nil {
+			break
+		}
+		prefix = append(prefix, b)
+		if b != expected {
+			break
+		}
+	}
+
+	if len(prefix) < 2 || prefix[1] != containerCompressed {
+		return &prefixedReader{prefix: prefix, reader: reader}, nil
+	}
+
+	version, err := (&varintReader{reader: reader}).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if version != CompressedVersion {
+		return nil, fmt.Errorf("unsupported compressed version: %d", version)
+	}
+
+	return bufio.NewReader(flate.NewReader(byteReaderAdapter{reader})), nil
+}
+
+// prefixedReader reads prefix before continuing with reader, for putting back
+// bytes already consumed.
+type prefixedReader struct {
+	prefix []byte
+	reader io.ByteReader
+}
+
+func (r *prefixedReader) ReadByte() (byte, error) {
+	if len(r.prefix) > 0 {
+		b := r.prefix[0]
+		r.prefix = r.prefix[1:]
+		return b, nil
+	}
+	return r.reader.ReadByte()
+}
+
+// byteReaderAdapter adapts an io.ByteReader to an io.Reader.
+type byteReaderAdapter struct {
+	io.ByteReader
+}
+
+func (r byteReaderAdapter) Read(p []byte) (int, error) {
+	for i := range p {
+		b, err := r.ReadByte()
+		if err != nil {
+			if i > 0 {
+				return i, nil
+			}
+			return 0, err
+		}
+		p[i] = b
+	}
+	return len(p), nil
+}
@@ -0,0 +1,69 @@
+package deckstrings_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func researchDeck() Deck {
+	deck := Deck{Format: FormatWild, Heroes: []uint64{637}}
+	for id := uint64(1000); id < 1400; id++ {
+		deck.Cards = append(deck.Cards, [2]uint64{id, 1 + id%2})
+	}
+	return deck
+}
+
+func TestCompress(t *testing.T) {
+	deck := researchDeck()
+
+	plain := MustEncode(deck)
+	compressed := MustEncode(deck, Compress())
+	assert.True(t, len(compressed) < len(plain))
+
+	decoded, err := Decode(compressed)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+
+	decoded, info, err := DecodeWithInfo(compressed)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+	assert.Equal(t, [3]int{200, 200, 0}, info.GroupLengths)
+
+	var b strings.Builder
+	_, err = EncodeTo(&b, deck, Compress())
+	assert.Nil(t, err)
+	assert.Equal(t, compressed, b.String())
+}
+
+func TestCompressStandardDeck(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	assert.Equal(t, deckstring, MustEncode(MustDecode(deckstring), Compress()))
+}
+
+func TestCompressInvalidDeck(t *testing.T) {
+	_, err := Encode(Deck{Cards: [][2]uint64{{1, 0}}}, Compress())
+	assert.EqualError(t, err, "deckstring encode: invalid card count for DBF ID 1")
+}
+
+func TestDecodeCompressedPayloadLimit(t *testing.T) {
+	compressed := MustEncode(researchDeck(), Compress())
+
+	_, err := Decode(compressed, MaxPayloadLength(100))
+	assert.EqualError(t, err, "deckstring decode: group 1 length 200 exceeds payload limit of 100 bytes")
+}
+
+func TestDecodeCompressedInvalid(t *testing.T) {
+	_, err := Decode(base64.StdEncoding.EncodeToString([]byte{0xFF, 'Z', 2}))
+	assert.EqualError(t, err, "deckstring decode: unsupported compressed version: 2")
+
+	_, err = Decode(base64.StdEncoding.EncodeToString([]byte{0xFF, 'Z', 1, 0xFF}))
+	assert.NotNil(t, err)
+
+	// Other containers are not deckstrings.
+	_, err = Decode(base64.StdEncoding.EncodeToString([]byte{0xFF, 'L', 1, 0}))
+	assert.EqualError(t, err, "deckstring decode: unexpected reserved byte: 9855")
+}
@@ -0,0 +1,98 @@
+package deckstrings
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Containers are encodings defined by this package that wrap deckstring
+// payloads, such as deck lists. A container payload begins with
+// containerMagic, a byte identifying the kind of container, and a varint
+// container version. Plain deckstring payloads begin with the reserved byte 0,
+// so the two can always be told apart.
+const containerMagic = 0xFF
+
+// Container kinds.
+const (
+	containerList       byte = 'L'
+	containerMetadata   byte = 'M'
+	containerCompressed byte = 'Z'
+	containerEncrypted  byte = 'E'
+)
+
+var containerNames = map[byte]string{
+	containerList:       "deck list",
+	containerMetadata:   "metadata",
+	containerCompressed: "compressed",
+	containerEncrypted:  "encrypted",
+}
+
+// writeContainerHeader writes the header of a container of the given kind.
+func writeContainerHeader(buf *bytes.Buffer, kind byte, version uint64) {
+	buf.WriteByte(containerMagic)
+	buf.WriteByte(kind)
+	(&varintWriter{buf}).Write(version)
+}
+
+// isContainer reports whether payload is a container of the given kind.
+func isContainer(payload []byte, kind byte) bool {
+	return len(payload) >= 2 && payload[0] == containerMagic && payload[1] == kind
+}
+
+// readContainerHeader reads the header of a container of the given kind from
+// payload, returning a reader positioned after it. Returns an error if the
+// container version is not the expected version.
+func readContainerHeader(payload []byte, kind byte, expected uint64) (*bytes.Reader, error) {
+	if !isContainer(payload, kind) {
+		return nil, fmt.Errorf("expected %s container", containerNames[kind])
+	}
+
+	reader := bytes.NewReader(payload[2:])
+	version, err := (&varintReader{reader: reader}).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if version != expected {
+		return nil, fmt.Errorf("unsupported container version: %d", version)
+	}
+
+	return reader, nil
+}
+
+// readBytes reads a varint length followed by that many bytes.
+func readBytes(reader *bytes.Reader) ([]byte, error) {
+	length, err := (&varintReader{reader: reader}).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if length > uint64(reader.Len()) {
+		return nil, fmt.Errorf("length %d exceeds remaining %d bytes", length, reader.Len())
+	}
+
+	data := make([]byte, length)
+	reader.Read(data)
+	return data, nil
+}
+
+// writeBytes writes a varint length followed by data.
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	(&varintWriter{buf}).Write(uint64(len(data)))
+	buf.Write(data)
+}
+
+// readString reads a length-prefixed UTF-8 string.
+func readString(reader *bytes.Reader) (string, error) {
+	data, err := readBytes(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("invalid UTF-8 string")
+	}
+
+	return string(data), nil
+}
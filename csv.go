@@ -0,0 +1,202 @@
+package deckstrings
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CSVWriter writes decks as CSV with one row per card, for spreadsheets and BI
+// tools. The columns are:
+//
+//	deckstring,format,hero,dbf_id,count
+//
+// The hero column holds the deck's hero DBF IDs separated by spaces. A deck
+// without cards is written as a single row with empty dbf_id and count columns.
+// If the writer has a CardDB, name and cost columns are appended; they are left
+// empty for cards not in the database.
+type CSVWriter struct {
+	writer      *csv.Writer
+	db          *CardDB
+	wroteHeader bool
+}
+
+// NewCSVWriter creates a CSVWriter writing to writer. The db argument is
+// optional and may be nil.
+func NewCSVWriter(writer io.Writer, db *CardDB) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(writer), db: db}
+}
+
+// Write writes the rows for a deck along with its deckstring. The header row is
+// written before the first deck.
+func (w *CSVWriter) Write(deckstring string, deck Deck) error {
+	if !w.wroteHeader {
+		header := []string{"deckstring", "format", "hero", "dbf_id", "count"}
+		if w.db != nil {
+			header = append(header, "name", "cost")
+		}
+		if err := w.writer.Write(header); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+
+	heroes := make([]string, len(deck.Heroes))
+	for i, hero := range deck.Heroes {
+		heroes[i] = strconv.FormatUint(hero, 10)
+	}
+
+	prefix := []string{deckstring, strconv.FormatUint(uint64(deck.Format), 10), strings.Join(heroes, " ")}
+
+	if len(deck.Cards) == 0 {
+		row := append(prefix, "", "")
+		if w.db != nil {
+			row = append(row, "", "")
+		}
+		return w.writer.Write(row)
+	}
+
+	for _, card := range deck.Cards {
+		row := append(prefix, strconv.FormatUint(card[0], 10), strconv.FormatUint(card[1], 10))
+		if w.db != nil {
+			if info, ok := w.db.Card(card[0]); ok {
+				row = append(row, info.Name, strconv.Itoa(info.Cost))
+			} else {
+				row = append(row, "", "")
+			}
+		}
+		if err := w.writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer and reports any
+// error that occurred during a previous Write or Flush.
+func (w *CSVWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ReadCSV reads decks written by CSVWriter. Columns are located by the names in
+// the header row, so additional columns (such as name and cost) are ignored and
+// columns may appear in any order. The deckstring, format, hero, dbf_id, and
+// count columns are required.
+//
+// Consecutive rows with the same deckstring form a deck. Each deck is returned
+// as a Record whose Line is that of the deck's first row. A deck with invalid
+// values, or whose rows disagree on format or heroes, has its Err set; other
+// decks are still read. A row with an empty deckstring is returned as a Record
+// of its own with Err set. Returns an error if the input is not valid CSV or if
+// a required column is missing.
+func ReadCSV(reader io.Reader) ([]Record, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "csv read")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	required := []string{"deckstring", "format", "hero", "dbf_id", "count"}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv read: missing column: %s", name)
+		}
+	}
+
+	var records []Record
+	var current *Record
+	var prefix []string
+
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "csv read")
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		// Rows without a deckstring can't be grouped into a deck, so each is a
+		// record of its own.
+		deckstring := field("deckstring")
+		if deckstring == "" {
+			records = append(records, Record{Line: line, Err: fmt.Errorf("line %d: missing deckstring", line)})
+			current = nil
+			continue
+		}
+
+		if current == nil || deckstring != current.Deckstring {
+			records = append(records, Record{Line: line, Deckstring: deckstring})
+			current = &records[len(records)-1]
+			prefix = []string{field("format"), field("hero")}
+
+			format, heroes, err := parseCSVDeck(prefix[0], prefix[1])
+			current.Deck = Deck{Format: format, Heroes: heroes, Cards: [][2]uint64{}}
+			current.Err = err
+		} else if current.Err == nil && (field("format") != prefix[0] || field("hero") != prefix[1]) {
+			current.Err = fmt.Errorf("line %d: format or hero differs from line %d", line, current.Line)
+		}
+
+		if current.Err != nil || (field("dbf_id") == "" && field("count") == "") {
+			continue
+		}
+
+		dbfID, err := strconv.ParseUint(field("dbf_id"), 10, 64)
+		if err != nil {
+			current.Err = fmt.Errorf("line %d: invalid dbf_id: %s", line, field("dbf_id"))
+			continue
+		}
+
+		count, err := strconv.ParseUint(field("count"), 10, 64)
+		if err != nil {
+			current.Err = fmt.Errorf("line %d: invalid count: %s", line, field("count"))
+			continue
+		}
+
+		current.Deck.Cards = append(current.Deck.Cards, [2]uint64{dbfID, count})
+	}
+
+	for i := range records {
+		if records[i].Err != nil {
+			records[i].Deck = Deck{}
+		}
+	}
+
+	return records, nil
+}
+
+func parseCSVDeck(format, hero string) (Format, []uint64, error) {
+	value, err := strconv.ParseUint(format, 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid format: %s", format)
+	}
+
+	fields := strings.Fields(hero)
+	heroes := make([]uint64, len(fields))
+	for i, field := range fields {
+		if heroes[i], err = strconv.ParseUint(field, 10, 64); err != nil {
+			return 0, nil, fmt.Errorf("invalid hero: %s", field)
+		}
+	}
+
+	return Format(value), heroes, nil
+}
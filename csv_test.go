@@ -0,0 +1,111 @@
+package deckstrings_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewCSVWriter(&buf, nil)
+
+	deck := Deck{Format: FormatStandard, Heroes: []uint64{31}, Cards: [][2]uint64{{141, 2}, {455, 1}}}
+	assert.Nil(t, writer.Write("AAECAR8BxwMBjQEA", deck))
+	assert.Nil(t, writer.Write("AAEAAAAAAA==", Deck{}))
+	assert.Nil(t, writer.Flush())
+
+	expected := `deckstring,format,hero,dbf_id,count
+AAECAR8BxwMBjQEA,2,31,141,2
+AAECAR8BxwMBjQEA,2,31,455,1
+AAEAAAAAAA==,0,,,
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestCSVWriterCardDB(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewCSVWriter(&buf, testCardDB(t))
+
+	deck := Deck{Format: FormatWild, Heroes: []uint64{637}, Cards: [][2]uint64{{315, 2}, {999999, 1}}}
+	assert.Nil(t, writer.Write("AAEBAf0EAAG7AgA=", deck))
+	assert.Nil(t, writer.Flush())
+
+	expected := `deckstring,format,hero,dbf_id,count,name,cost
+AAEBAf0EAAG7AgA=,1,637,315,2,Fireball,4
+AAEBAf0EAAG7AgA=,1,637,999999,1,,
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	decks := []Deck{
+		{Format: FormatStandard, Heroes: []uint64{31}, Cards: [][2]uint64{{141, 2}, {455, 1}}},
+		{Format: FormatWild, Heroes: []uint64{7, 637}, Cards: [][2]uint64{{315, 3}}},
+		{Format: Format(0), Heroes: []uint64{}, Cards: [][2]uint64{}},
+	}
+
+	var buf bytes.Buffer
+	writer := NewCSVWriter(&buf, testCardDB(t))
+	for _, deck := range decks {
+		deckstring, err := Encode(deck)
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Write(deckstring, deck))
+	}
+	assert.Nil(t, writer.Flush())
+
+	records, err := ReadCSV(&buf)
+	assert.Nil(t, err)
+	assert.Len(t, records, len(decks))
+	for i, record := range records {
+		assert.Nil(t, record.Err)
+		assert.Equal(t, decks[i], record.Deck)
+
+		decoded, err := Decode(record.Deckstring)
+		assert.Nil(t, err)
+		assert.Equal(t, decks[i], decoded)
+	}
+	assert.Equal(t, []int{2, 4, 5}, []int{records[0].Line, records[1].Line, records[2].Line})
+}
+
+func TestReadCSVInvalidDecks(t *testing.T) {
+	input := `count,dbf_id,hero,format,deckstring
+2,141,31,2,a
+1,455,7,2,a
+x,1,31,2,b
+1,1,31,2,c
+`
+	records, err := ReadCSV(strings.NewReader(input))
+	assert.Nil(t, err)
+	assert.Len(t, records, 3)
+	assert.NotNil(t, records[0].Err)
+	assert.NotNil(t, records[1].Err)
+	assert.Nil(t, records[2].Err)
+	assert.Equal(t, [][2]uint64{{1, 1}}, records[2].Deck.Cards)
+}
+
+func TestReadCSVMissingDeckstring(t *testing.T) {
+	input := `deckstring,format,hero,dbf_id,count
+,2,31,141,2
+,2,31,455,1
+c,2,31,1,1
+`
+	records, err := ReadCSV(strings.NewReader(input))
+	assert.Nil(t, err)
+	assert.Len(t, records, 3)
+	assert.EqualError(t, records[0].Err, "line 2: missing deckstring")
+	assert.EqualError(t, records[1].Err, "line 3: missing deckstring")
+	assert.Nil(t, records[2].Err)
+	assert.Equal(t, 4, records[2].Line)
+}
+
+func TestReadCSVMissingColumn(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("deckstring,format,hero,dbf_id\n"))
+	assert.NotNil(t, err)
+
+	_, err = ReadCSV(strings.NewReader(""))
+	assert.NotNil(t, err)
+}
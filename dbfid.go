@@ -0,0 +1,31 @@
+package deckstrings
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// DBFID is a Hearthstone DBF ID. Its String method includes the card's name
+// when a card database has been registered with RegisterCardDB, e.g.
+// "Fireball (315)", making logs and debugger output easier to read.
+type DBFID uint64
+
+var registeredCardDB atomic.Pointer[CardDB]
+
+// RegisterCardDB sets the card database used to resolve names in
+// DBFID.String. Passing nil unregisters the current database. It is safe to
+// call concurrently with DBFID.String.
+func RegisterCardDB(db *CardDB) {
+	registeredCardDB.Store(db)
+}
+
+// String returns the card's name followed by its DBF ID in parentheses, or
+// just the DBF ID if no card database is registered or the card is unknown.
+func (id DBFID) String() string {
+	if db := registeredCardDB.Load(); db != nil {
+		if card, ok := db.Card(uint64(id)); ok && card.Name != "" {
+			return fmt.Sprintf("%s (%d)", card.Name, uint64(id))
+		}
+	}
+	return fmt.Sprintf("%d", uint64(id))
+}
@@ -0,0 +1,29 @@
+package deckstrings_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBFIDString(t *testing.T) {
+	assert.Equal(t, "315", DBFID(315).String())
+
+	RegisterCardDB(testCardDB(t))
+	defer RegisterCardDB(nil)
+
+	assert.Equal(t, "Fireball (315)", DBFID(315).String())
+	assert.Equal(t, "99999", DBFID(99999).String())
+	assert.Equal(t, "2x Fireball (315)", fmt.Sprint(Card{DBFID: 315, Count: 2}))
+}
+
+func TestDBFIDDetails(t *testing.T) {
+	deck := Deck{Format: FormatWild, Heroes: []uint64{637}, Cards: [][2]uint64{{315, 2}}}
+
+	RegisterCardDB(testCardDB(t))
+	defer RegisterCardDB(nil)
+
+	assert.Equal(t, "Format: Wild\nHeroes: Jaina Proudmoore (637)\nCards: 2 (1 distinct)\n  2x Fireball (315)\n", deck.Details())
+}
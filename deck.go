@@ -0,0 +1,193 @@
+package deckstrings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// String returns a compact, single-line summary of the deck suitable for logs,
+// e.g. "Standard, 1 hero, 30 cards (18 distinct)". See Details for a multiline
+// variant that lists every card.
+func (d Deck) String() string {
+	total, distinct := d.cardCounts()
+	return fmt.Sprintf("%s, %s, %s (%d distinct)",
+		d.Format,
+		plural(uint64(len(d.Heroes)), "hero", "heroes"),
+		plural(total, "card", "cards"),
+		distinct)
+}
+
+// Details returns a verbose, multiline description of the deck: its format,
+// heroes, and every card with its count, in the order stored in the deck.
+// Heroes and cards include names if a card database is registered; see DBFID.
+func (d Deck) Details() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Format: %s\n", d.Format)
+
+	heroes := make([]string, len(d.Heroes))
+	for i, hero := range d.Heroes {
+		heroes[i] = DBFID(hero).String()
+	}
+	fmt.Fprintf(&b, "Heroes: %s\n", strings.Join(heroes, ", "))
+
+	total, distinct := d.cardCounts()
+	fmt.Fprintf(&b, "Cards: %d (%d distinct)\n", total, distinct)
+	for _, card := range d.CardList() {
+		fmt.Fprintf(&b, "  %s\n", card)
+	}
+
+	return b.String()
+}
+
+// TotalCards returns the total number of cards in the deck: the sum of every
+// card's count, including duplicate entries for the same DBF ID.
+func (d Deck) TotalCards() uint64 {
+	total, _ := d.cardCounts()
+	return total
+}
+
+// CountOf returns the number of copies of the card with the given DBF ID in
+// the deck, summed across duplicate entries. Returns 0 if the card is absent.
+func (d Deck) CountOf(dbfID uint64) uint64 {
+	var count uint64
+	for _, card := range d.Cards {
+		if card[0] == dbfID {
+			count += card[1]
+		}
+	}
+	return count
+}
+
+// CardsMap returns the deck's cards as a map from DBF ID to count. Duplicate
+// entries for a DBF ID are summed.
+func (d Deck) CardsMap() map[uint64]uint64 {
+	return cardCountsByID(d.Cards)
+}
+
+// FromCardsMap creates a canonical deck from a map of DBF ID to count. Cards
+// with a count of 0 are omitted.
+func FromCardsMap(format Format, heroes []uint64, cards map[uint64]uint64) Deck {
+	deck := Deck{
+		Format: format,
+		Heroes: sortedHeroes(heroes),
+		Cards:  make([][2]uint64, 0, len(cards)),
+	}
+	for _, dbfID := range sortedKeys(cards) {
+		if count := cards[dbfID]; count > 0 {
+			deck.Cards = append(deck.Cards, [2]uint64{dbfID, count})
+		}
+	}
+	return deck
+}
+
+// Clone returns a deep copy of the deck. Mutating the clone's heroes or cards
+// does not affect the original. Nil slices remain nil.
+func (d Deck) Clone() Deck {
+	clone := Deck{Format: d.Format, Version: d.Version}
+	if d.Heroes != nil {
+		clone.Heroes = append(make([]uint64, 0, len(d.Heroes)), d.Heroes...)
+	}
+	if d.Cards != nil {
+		clone.Cards = append(make([][2]uint64, 0, len(d.Cards)), d.Cards...)
+	}
+	return clone
+}
+
+// Canonicalize returns a normalized copy of the deck: heroes are sorted by
+// DBF ID, duplicate entries for a card are merged by summing their counts, and
+// cards are sorted by DBF ID. This is the form produced by Decode and is
+// useful before comparing or storing decks constructed by hand. The given deck
+// is not modified.
+func Canonicalize(deck Deck) Deck {
+	counts := cardCountsByID(deck.Cards)
+
+	canonical := Deck{
+		Format:  deck.Format,
+		Heroes:  sortedHeroes(deck.Heroes),
+		Cards:   make([][2]uint64, 0, len(counts)),
+		Version: deck.Version,
+	}
+	for _, dbfID := range sortedKeys(counts) {
+		canonical.Cards = append(canonical.Cards, [2]uint64{dbfID, counts[dbfID]})
+	}
+
+	return canonical
+}
+
+// Equal reports whether two decks are the same deck: they have the same
+// format, heroes, card counts, and version, regardless of the order of heroes
+// and cards or whether a card's copies are split across duplicate entries. A
+// Version of 0 is the same as Version.
+func Equal(a, b Deck) bool {
+	if a.Format != b.Format || a.version() != b.version() {
+		return false
+	}
+
+	if !equalHeroes(sortedHeroes(a.Heroes), sortedHeroes(b.Heroes)) {
+		return false
+	}
+
+	x, y := cardCountsByID(a.Cards), cardCountsByID(b.Cards)
+	if len(x) != len(y) {
+		return false
+	}
+	for dbfID, count := range x {
+		if other, ok := y[dbfID]; !ok || other != count {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether d and other are the same deck. See Equal.
+func (d Deck) Equal(other Deck) bool {
+	return Equal(d, other)
+}
+
+// cardCounts returns the sum of all card counts and the number of distinct
+// DBF IDs in the deck. Duplicate entries for a DBF ID are counted once.
+func (d Deck) cardCounts() (total uint64, distinct int) {
+	seen := make(map[uint64]bool, len(d.Cards))
+	for _, card := range d.Cards {
+		total += card[1]
+		seen[card[0]] = true
+	}
+	return total, len(seen)
+}
+
+// version returns the deck's encoding version, treating 0 as Version.
+func (d Deck) version() uint64 {
+	if d.Version == 0 {
+		return Version
+	}
+	return d.Version
+}
+
+func plural(n uint64, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// cardCountsByID sums card counts by DBF ID.
+func cardCountsByID(cards [][2]uint64) map[uint64]uint64 {
+	counts := make(map[uint64]uint64, len(cards))
+	for _, card := range cards {
+		counts[card[0]] += card[1]
+	}
+	return counts
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[uint64]uint64) []uint64 {
+	keys := make([]uint64, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
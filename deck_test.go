@@ -0,0 +1,164 @@
+package deckstrings_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeckString(t *testing.T) {
+	deck := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{141, 2}, {216, 2}, {455, 1}},
+	}
+	assert.Equal(t, "Standard, 1 hero, 5 cards (3 distinct)", deck.String())
+	assert.Equal(t, "Format(0), 0 heroes, 0 cards (0 distinct)", Deck{}.String())
+}
+
+func TestDeckStringDuplicates(t *testing.T) {
+	deck := Deck{
+		Format: FormatWild,
+		Heroes: []uint64{7, 31},
+		Cards:  [][2]uint64{{1, 1}, {1, 1}},
+	}
+	assert.Equal(t, "Wild, 2 heroes, 2 cards (1 distinct)", fmt.Sprint(deck))
+}
+
+func TestDeckDetails(t *testing.T) {
+	deck := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{141, 2}, {455, 1}},
+	}
+	expected := "Format: Standard\nHeroes: 31\nCards: 3 (2 distinct)\n  2x 141\n  1x 455\n"
+	assert.Equal(t, expected, deck.Details())
+}
+
+func TestEqual(t *testing.T) {
+	a := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31, 7},
+		Cards:  [][2]uint64{{455, 1}, {141, 2}},
+	}
+	b := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{7, 31},
+		Cards:  [][2]uint64{{141, 1}, {455, 1}, {141, 1}},
+	}
+	assert.True(t, Equal(a, b))
+	assert.True(t, b.Equal(a))
+	assert.True(t, Deck{}.Equal(Deck{Heroes: []uint64{}, Cards: [][2]uint64{}}))
+	assert.True(t, Deck{}.Equal(Deck{Version: Version}))
+	assert.False(t, Deck{}.Equal(Deck{Version: 2}))
+}
+
+func TestNotEqual(t *testing.T) {
+	deck := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{141, 2}, {455, 1}},
+	}
+
+	other := deck
+	other.Format = FormatWild
+	assert.False(t, deck.Equal(other))
+
+	other = deck
+	other.Heroes = []uint64{7}
+	assert.False(t, deck.Equal(other))
+
+	other = deck
+	other.Cards = [][2]uint64{{141, 2}, {455, 2}}
+	assert.False(t, deck.Equal(other))
+
+	other = deck
+	other.Cards = [][2]uint64{{141, 2}, {456, 1}}
+	assert.False(t, deck.Equal(other))
+
+	other = deck
+	other.Cards = [][2]uint64{{141, 2}}
+	assert.False(t, deck.Equal(other))
+}
+
+func TestCanonicalize(t *testing.T) {
+	deck := Deck{
+		Format: FormatWild,
+		Heroes: []uint64{31, 7},
+		Cards:  [][2]uint64{{455, 1}, {141, 1}, {216, 2}, {141, 1}},
+	}
+
+	canonical := Canonicalize(deck)
+	assert.Equal(t, Deck{
+		Format: FormatWild,
+		Heroes: []uint64{7, 31},
+		Cards:  [][2]uint64{{141, 2}, {216, 2}, {455, 1}},
+	}, canonical)
+	assert.True(t, Equal(deck, canonical))
+
+	// The original deck is left untouched.
+	assert.Equal(t, []uint64{31, 7}, deck.Heroes)
+	assert.Equal(t, [2]uint64{455, 1}, deck.Cards[0])
+}
+
+func TestCanonicalizeDecoded(t *testing.T) {
+	deck, err := Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Equal(t, deck, Canonicalize(deck))
+}
+
+func TestClone(t *testing.T) {
+	deck := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{141, 2}, {455, 1}},
+	}
+
+	clone := deck.Clone()
+	assert.Equal(t, deck, clone)
+
+	clone.Heroes[0] = 7
+	clone.Cards[0][1] = 1
+	clone.Cards = append(clone.Cards, [2]uint64{500, 1})
+
+	assert.Equal(t, []uint64{31}, deck.Heroes)
+	assert.Equal(t, [][2]uint64{{141, 2}, {455, 1}}, deck.Cards)
+}
+
+func TestCloneEmpty(t *testing.T) {
+	assert.Equal(t, Deck{}, Deck{}.Clone())
+
+	empty := Deck{Heroes: []uint64{}, Cards: [][2]uint64{}}
+	assert.Equal(t, empty, empty.Clone())
+}
+
+func TestTotalCards(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{141, 2}, {455, 1}, {141, 1}}}
+	assert.Equal(t, uint64(4), deck.TotalCards())
+	assert.Equal(t, uint64(0), Deck{}.TotalCards())
+}
+
+func TestCountOf(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{141, 2}, {455, 1}, {141, 1}}}
+	assert.Equal(t, uint64(3), deck.CountOf(141))
+	assert.Equal(t, uint64(1), deck.CountOf(455))
+	assert.Equal(t, uint64(0), deck.CountOf(31))
+}
+
+func TestCardsMap(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{141, 2}, {455, 1}, {141, 1}}}
+	assert.Equal(t, map[uint64]uint64{141: 3, 455: 1}, deck.CardsMap())
+	assert.Equal(t, map[uint64]uint64{}, Deck{}.CardsMap())
+}
+
+func TestFromCardsMap(t *testing.T) {
+	deck := FromCardsMap(FormatWild, []uint64{31, 7}, map[uint64]uint64{455: 1, 141: 2, 216: 0})
+	assert.Equal(t, Deck{
+		Format: FormatWild,
+		Heroes: []uint64{7, 31},
+		Cards:  [][2]uint64{{141, 2}, {455, 1}},
+	}, deck)
+	assert.Equal(t, map[uint64]uint64{141: 2, 455: 1}, deck.CardsMap())
+}
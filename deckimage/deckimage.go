@@ -0,0 +1,289 @@
+// Package deckimage renders Hearthstone decks as images, e.g. for Discord bots
+// and social sharing.
+//
+// A rendered deck is a list with a header showing the deck's title on a band
+// of its class color, followed by a row per card showing the card's cost in a
+// mana gem, its name, and its count. Card metadata comes from a
+// deckstrings.CardDB, and card art can optionally be drawn behind each row.
+//
+// This package is its own module, keeping golang.org/x/image out of the
+// dependencies of programs that only use deckstrings.
+package deckimage
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/schmich/deckstrings"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// ArtSource provides art for cards, such as tile images from
+// HearthstoneJSON's art service. Art returns false if it has no art for a
+// card.
+type ArtSource interface {
+	Art(card deckstrings.CardInfo) (image.Image, bool)
+}
+
+// ArtFunc adapts a function to an ArtSource.
+type ArtFunc func(card deckstrings.CardInfo) (image.Image, bool)
+
+// Art calls f(card).
+func (f ArtFunc) Art(card deckstrings.CardInfo) (image.Image, bool) {
+	return f(card)
+}
+
+// Options configures how a deck is rendered. The zero value is valid.
+type Options struct {
+	// Title is shown in the header. It defaults to the name of the deck's
+	// class, e.g. "Mage".
+	Title string
+
+	// Width is the width of the image in pixels. It defaults to 320.
+	Width int
+
+	// Art optionally provides art drawn on the right of each card's row.
+	Art ArtSource
+}
+
+const (
+	defaultWidth = 320
+	rowHeight    = 28
+	headerHeight = 40
+	gemSize      = 22
+	padding      = 6
+)
+
+// ClassColors maps HearthstoneJSON class names to the colors used for deck
+// headers. Decks of other classes use a neutral gray.
+var ClassColors = map[string]color.RGBA{
+	"DEATHKNIGHT": {0x5b, 0x7b, 0x92, 0xff},
+	"DEMONHUNTER": {0x2b, 0x6b, 0x2f, 0xff},
+	"DRUID":       {0x70, 0x4a, 0x16, 0xff},
+	"HUNTER":      {0x01, 0x6e, 0x01, 0xff},
+	"MAGE":        {0x0a, 0x5c, 0xb5, 0xff},
+	"PALADIN":     {0xaa, 0x8f, 0x00, 0xff},
+	"PRIEST":      {0x9a, 0x9a, 0x9a, 0xff},
+	"ROGUE":       {0x4c, 0x4d, 0x48, 0xff},
+	"SHAMAN":      {0x2e, 0x3f, 0x9e, 0xff},
+	"WARLOCK":     {0x7c, 0x1f, 0x9e, 0xff},
+	"WARRIOR":     {0x8e, 0x10, 0x02, 0xff},
+}
+
+var (
+	neutralColor    = color.RGBA{0x5a, 0x5a, 0x5a, 0xff}
+	backgroundColor = color.RGBA{0x1e, 0x1b, 0x17, 0xff}
+	rowColor        = color.RGBA{0x2c, 0x27, 0x21, 0xff}
+	gemColor        = color.RGBA{0x1f, 0x6f, 0xd1, 0xff}
+	legendaryColor  = color.RGBA{0xf2, 0xa3, 0x1b, 0xff}
+)
+
+// Render draws a deck list, with cards in the order the game client lists
+// them.
+//
+// Returns a deckstrings.UnknownCardError if a card in the deck is not in db.
+func Render(deck deckstrings.Deck, db *deckstrings.CardDB, opts Options) (image.Image, error) {
+	cards, err := deckstrings.Enrich(deck, db)
+	if err != nil {
+		return nil, err
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	face, err := newFace(14)
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	class := deckstrings.HeroClass(deck, db)
+	title := opts.Title
+	if title == "" {
+		title = deckstrings.ClassName(deck, db)
+	}
+	classColor, ok := ClassColors[class]
+	if !ok {
+		classColor = neutralColor
+	}
+
+	height := headerHeight + len(cards)*(rowHeight+2) + padding
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fill(img, img.Bounds(), backgroundColor)
+
+	fill(img, image.Rect(0, 0, width, headerHeight), classColor)
+	drawText(img, face, title, padding*2, headerHeight/2, color.White)
+
+	for i, card := range cards {
+		top := headerHeight + padding + i*(rowHeight+2)
+		row := image.Rect(padding, top, width-padding, top+rowHeight)
+		fill(img, row, rowColor)
+
+		if opts.Art != nil {
+			if art, ok := opts.Art.Art(card.CardInfo); ok {
+				drawArt(img, art, image.Rect(row.Max.X-row.Dx()/2, row.Min.Y, row.Max.X, row.Max.Y))
+			}
+		}
+
+		gem := image.Rect(row.Min.X+3, row.Min.Y+3, row.Min.X+3+gemSize, row.Min.Y+3+gemSize)
+		drawGem(img, gem, gemColor)
+		drawTextCentered(img, face, strconv.Itoa(card.Cost), gem, color.White)
+
+		drawText(img, face, card.Name, gem.Max.X+padding, row.Min.Y+rowHeight/2, color.White)
+
+		// The bundled font has no star glyph, so legendaries' star is drawn.
+		box := image.Rect(row.Max.X-rowHeight, row.Min.Y, row.Max.X, row.Max.Y)
+		if card.Rarity == deckstrings.RarityLegendary && card.Count == 1 {
+			fill(img, box, backgroundColor)
+			drawStar(img, box, legendaryColor)
+		} else if card.Count > 1 {
+			fill(img, box, backgroundColor)
+			drawTextCentered(img, face, strconv.FormatUint(card.Count, 10), box, color.White)
+		}
+	}
+
+	return img, nil
+}
+
+// Encode renders a deck like Render and writes it to writer as a PNG.
+func Encode(writer io.Writer, deck deckstrings.Deck, db *deckstrings.CardDB, opts Options) error {
+	img, err := Render(deck, db, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(writer, img)
+}
+
+// EncodeBytes is like Encode but returns the PNG data.
+func EncodeBytes(deck deckstrings.Deck, db *deckstrings.CardDB, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, deck, db, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newFace(size float64) (font.Face, error) {
+	parsed, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("font parse: %v", err)
+	}
+	return opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+func fill(img draw.Image, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawArt scales art to cover rect, cropping it to rect's aspect ratio.
+func drawArt(img draw.Image, art image.Image, rect image.Rectangle) {
+	src := art.Bounds()
+	if src.Empty() {
+		return
+	}
+
+	// Crop the source to the destination's aspect ratio, keeping the center.
+	if src.Dx()*rect.Dy() > rect.Dx()*src.Dy() {
+		w := src.Dy() * rect.Dx() / rect.Dy()
+		src.Min.X += (src.Dx() - w) / 2
+		src.Max.X = src.Min.X + w
+	} else {
+		h := src.Dx() * rect.Dy() / rect.Dx()
+		src.Min.Y += (src.Dy() - h) / 2
+		src.Max.Y = src.Min.Y + h
+	}
+
+	draw.CatmullRom.Scale(img, rect, art, src, draw.Over, nil)
+}
+
+// drawGem draws a diamond filling rect.
+func drawGem(img draw.Image, rect image.Rectangle, c color.Color) {
+	cx, cy := (rect.Min.X+rect.Max.X)/2, (rect.Min.Y+rect.Max.Y)/2
+	r := rect.Dx() / 2
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if abs(x-cx)+abs(y-cy) <= r {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// drawStar draws a five-pointed star centered in rect.
+func drawStar(img draw.Image, rect image.Rectangle, c color.Color) {
+	cx := float64(rect.Min.X+rect.Max.X) / 2
+	cy := float64(rect.Min.Y+rect.Max.Y) / 2
+	outer := float64(min(rect.Dx(), rect.Dy())) * 0.35
+	inner := outer * 0.45
+
+	// Vertices alternate between outer points and inner corners, starting
+	// from the top point.
+	var xs, ys [10]float64
+	for i := range xs {
+		r := outer
+		if i%2 == 1 {
+			r = inner
+		}
+		angle := -math.Pi/2 + float64(i)*math.Pi/5
+		xs[i] = cx + r*math.Cos(angle)
+		ys[i] = cy + r*math.Sin(angle)
+	}
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if inPolygon(float64(x)+0.5, float64(y)+0.5, xs[:], ys[:]) {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// inPolygon reports whether (x, y) is inside the polygon with the given
+// vertices, using the even-odd rule.
+func inPolygon(x, y float64, xs, ys []float64) bool {
+	inside := false
+	for i, j := 0, len(xs)-1; i < len(xs); j, i = i, i+1 {
+		if (ys[i] > y) != (ys[j] > y) && x < xs[i]+(y-ys[i])*(xs[j]-xs[i])/(ys[j]-ys[i]) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// drawText draws s starting at x, vertically centered on y.
+func drawText(img draw.Image, face font.Face, s string, x, y int, c color.Color) {
+	metrics := face.Metrics()
+	baseline := y + (metrics.Ascent-metrics.Descent).Ceil()/2
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, baseline),
+	}
+	drawer.DrawString(s)
+}
+
+// drawTextCentered draws s centered in rect.
+func drawTextCentered(img draw.Image, face font.Face, s string, rect image.Rectangle, c color.Color) {
+	width := font.MeasureString(face, s).Ceil()
+	drawText(img, face, s, rect.Min.X+(rect.Dx()-width)/2, (rect.Min.Y+rect.Max.Y)/2, c)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
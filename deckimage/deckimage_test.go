@@ -0,0 +1,125 @@
+package deckimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/deckimage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCardDB(t *testing.T) *deckstrings.CardDB {
+	file, err := os.Open("../testdata/cards.json")
+	require.Nil(t, err)
+	defer file.Close()
+
+	db, err := deckstrings.LoadCardDB(file)
+	require.Nil(t, err)
+	return db
+}
+
+var testDeck = deckstrings.Deck{
+	Format: deckstrings.FormatStandard,
+	Heroes: []uint64{637},
+	Cards:  [][2]uint64{{315, 2}, {581, 1}, {662, 2}},
+}
+
+func TestRender(t *testing.T) {
+	img, err := deckimage.Render(testDeck, testCardDB(t), deckimage.Options{})
+	require.Nil(t, err)
+
+	assert.Equal(t, image.Rect(0, 0, 320, 40+3*30+6), img.Bounds())
+
+	r, g, b, _ := img.At(1, 1).RGBA()
+	mage := deckimage.ClassColors["MAGE"]
+	assert.Equal(t, [3]uint32{uint32(mage.R) * 0x101, uint32(mage.G) * 0x101, uint32(mage.B) * 0x101}, [3]uint32{r, g, b})
+}
+
+func TestRenderArt(t *testing.T) {
+	art := image.NewRGBA(image.Rect(0, 0, 120, 40))
+	for i := range art.Pix {
+		art.Pix[i] = 0xff
+	}
+
+	var names []string
+	source := deckimage.ArtFunc(func(card deckstrings.CardInfo) (image.Image, bool) {
+		names = append(names, card.Name)
+		if card.DBFID == 662 {
+			return image.NewRGBA(image.Rect(0, 0, 0, 0)), true
+		}
+		return art, card.DBFID == 315
+	})
+
+	img, err := deckimage.Render(testDeck, testCardDB(t), deckimage.Options{Title: "Freeze", Width: 200, Art: source})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Frostbolt", "Fireball", "Alexstrasza"}, names)
+	assert.Equal(t, 200, img.Bounds().Dx())
+
+	// Fireball's art is drawn on the right of its row.
+	r, g, b, _ := img.At(130, 40+6+30+14).RGBA()
+	assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+}
+
+func TestEncode(t *testing.T) {
+	data, err := deckimage.EncodeBytes(testDeck, testCardDB(t), deckimage.Options{})
+	require.Nil(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.Nil(t, err)
+	assert.Equal(t, 320, img.Bounds().Dx())
+}
+
+func TestRenderUnknownCard(t *testing.T) {
+	_, err := deckimage.Render(deckstrings.Deck{Cards: [][2]uint64{{999999, 1}}}, testCardDB(t), deckimage.Options{})
+	assert.Equal(t, deckstrings.UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestRenderCounts(t *testing.T) {
+	img, err := deckimage.Render(testDeck, testCardDB(t), deckimage.Options{})
+	require.Nil(t, err)
+
+	rgb := func(x, y int) [3]uint32 {
+		r, g, b, _ := img.At(x, y).RGBA()
+		return [3]uint32{r >> 8, g >> 8, b >> 8}
+	}
+	background := [3]uint32{0x1e, 0x1b, 0x17}
+	legendary := [3]uint32{0xf2, 0xa3, 0x1b}
+
+	// Each row's count box is the rightmost 28 pixels of the row, which
+	// spans x = 6 to 314 and starts 46 + 30*i pixels down.
+	countBox := func(i int) image.Rectangle {
+		top := 46 + 30*i
+		return image.Rect(286, top, 314, top+28)
+	}
+	colors := func(box image.Rectangle) map[[3]uint32]int {
+		counts := make(map[[3]uint32]int)
+		for y := box.Min.Y; y < box.Max.Y; y++ {
+			for x := box.Min.X; x < box.Max.X; x++ {
+				counts[rgb(x, y)]++
+			}
+		}
+		return counts
+	}
+
+	// Frostbolt's count, 2, is drawn in white on the background.
+	frostbolt := colors(countBox(0))
+	assert.True(t, frostbolt[[3]uint32{0xff, 0xff, 0xff}] > 10)
+	assert.True(t, frostbolt[background] > 28*28/2)
+	assert.Equal(t, 0, frostbolt[legendary])
+
+	// Alexstrasza's star is drawn in the legendary color, with its center
+	// and top point filled and its corners empty.
+	box := countBox(2)
+	star := colors(box)
+	assert.True(t, star[legendary] > 50)
+	assert.Equal(t, 0, star[[3]uint32{0xff, 0xff, 0xff}])
+	assert.Equal(t, legendary, rgb(box.Min.X+14, box.Min.Y+14))
+	assert.Equal(t, legendary, rgb(box.Min.X+14, box.Min.Y+7))
+	assert.Equal(t, background, rgb(box.Min.X+1, box.Min.Y+1))
+	assert.Equal(t, background, rgb(box.Max.X-2, box.Min.Y+1))
+}
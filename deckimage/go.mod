@@ -0,0 +1,19 @@
+module github.com/schmich/deckstrings/deckimage
+
+go 1.23.0
+
+require (
+	github.com/schmich/deckstrings v0.0.0-20261017033210-bd75c6128c75
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/image v0.25.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pkg/errors v0.8.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/schmich/deckstrings => ../
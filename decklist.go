@@ -0,0 +1,177 @@
+package deckstrings
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// UnresolvedLine is a line of a deck list that could not be turned into a
+// card. Line is the 1-based line number.
+type UnresolvedLine struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (u UnresolvedLine) String() string {
+	return fmt.Sprintf("line %d: %s: %q", u.Line, u.Reason, u.Text)
+}
+
+var (
+	// "2x Fireball", "2 x Fireball", "2 Fireball", "x2 Fireball"
+	leadingCount = regexp.MustCompile(`^(?:(\d+)\s*[x×]?|[x×]\s*(\d+))\s+(.+)$`)
+
+	// "Fireball x2", "Fireball ×2"
+	trailingCount = regexp.MustCompile(`^(.+?)\s+[x×]\s*(\d+)$`)
+
+	// "(4) Fireball", "Fireball (4)"
+	leadingCost  = regexp.MustCompile(`^\((\d+)\)\s*(.+)$`)
+	trailingCost = regexp.MustCompile(`^(.+?)\s*\((\d+)\)$`)
+
+	// "# Class: Mage", "# Format: Wild"
+	headerLine = regexp.MustCompile(`^#+\s*(\p{L}+)\s*:\s*(.+)$`)
+)
+
+// ParseDeckList parses a human-typed deck list with one card per line, such as
+// "2x Fireball" or "1 Alexstrasza", resolving card names through db. Counts
+// may be written as "2x", "2", or a trailing "x2", and default to 1. Mana
+// costs in parentheses, e.g. "2x (4) Fireball", are ignored except to choose
+// between cards sharing a name. Blank lines and lines that are deckstrings are
+// skipped, as are comment lines starting with '#' that don't list a card, so
+// the text Hearthstone copies to the clipboard can be parsed too.
+//
+// Card names are compared case-insensitively in any locale loaded into db, so
+// lists exported by non-English game clients can be parsed with a CardDB
+// loaded from HearthstoneJSON's all-locales data. When several cards share a
+// name, collectible cards matching the given cost are preferred, then the
+// lowest DBF ID. The deck's class and format are taken from "# Class:" and
+// "# Format:" lines if present; localized header lines are skipped. Otherwise
+// the hero is the default hero for the class of the deck's class cards, if
+// they share one, and the format is FormatWild.
+//
+// Lines that can't be resolved are returned rather than failing the parse.
+func ParseDeckList(text string, db *CardDB) (Deck, []UnresolvedLine) {
+	var unresolved []UnresolvedLine
+	counts := make(map[uint64]uint64)
+	format := FormatWild
+	class := ""
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		comment := strings.HasPrefix(line, "#")
+		if match := headerLine.FindStringSubmatch(line); match != nil {
+			switch strings.ToLower(match[1]) {
+			case "class":
+				class = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(match[2]), " ", ""))
+			case "format":
+				if f, ok := formatByName(strings.TrimSpace(match[2])); ok {
+					format = f
+				}
+			}
+			continue
+		}
+
+		if _, err := Decode(line); err == nil {
+			continue
+		}
+
+		name, count, cost, listed := parseDeckListLine(strings.TrimLeft(line, "#-*• \t"))
+		if comment && !listed {
+			continue
+		}
+
+		card, ok := resolveCardName(db, name, cost)
+		if !ok {
+			unresolved = append(unresolved, UnresolvedLine{Line: i + 1, Text: line, Reason: "unknown card"})
+			continue
+		}
+		if count == 0 {
+			unresolved = append(unresolved, UnresolvedLine{Line: i + 1, Text: line, Reason: "invalid count"})
+			continue
+		}
+
+		counts[card.DBFID] += count
+	}
+
+	var heroes []uint64
+	if class == "" {
+		class = deckClass(counts, db)
+	}
+	if hero, ok := DefaultHeroes[class]; ok {
+		heroes = []uint64{hero}
+	}
+
+	return FromCardsMap(format, heroes, counts), unresolved
+}
+
+// parseDeckListLine splits a deck list line into a card name, count, and cost.
+// A cost of -1 means none was given. listed reports whether the line gave an
+// explicit count.
+func parseDeckListLine(line string) (name string, count uint64, cost int, listed bool) {
+	name, count, cost = line, 1, -1
+
+	if match := leadingCount.FindStringSubmatch(name); match != nil {
+		count, _ = strconv.ParseUint(match[1]+match[2], 10, 64)
+		name, listed = match[3], true
+	} else if match := trailingCount.FindStringSubmatch(name); match != nil {
+		count, _ = strconv.ParseUint(match[2], 10, 64)
+		name, listed = match[1], true
+	}
+
+	if match := leadingCost.FindStringSubmatch(name); match != nil {
+		cost, _ = strconv.Atoi(match[1])
+		name = match[2]
+	} else if match := trailingCost.FindStringSubmatch(name); match != nil {
+		cost, _ = strconv.Atoi(match[2])
+		name = match[1]
+	}
+
+	return strings.TrimSpace(name), count, cost, listed
+}
+
+// resolveCardName finds the card with the given name, preferring collectible
+// cards with the given cost (-1 for any), then the lowest DBF ID.
+func resolveCardName(db *CardDB, name string, cost int) (CardInfo, bool) {
+	cards := db.CardsByName(name)
+	if len(cards) == 0 {
+		return CardInfo{}, false
+	}
+
+	best, bestScore := cards[0], -1
+	for _, card := range cards {
+		score := 0
+		if card.Collectible {
+			score += 2
+		}
+		if cost < 0 || card.Cost == cost {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = card, score
+		}
+	}
+	return best, true
+}
+
+// deckClass returns the class shared by the deck's class cards, or "" if
+// there is none or more than one.
+func deckClass(counts map[uint64]uint64, db *CardDB) string {
+	class := ""
+	for dbfID := range counts {
+		card, _ := db.Card(dbfID)
+		if card.Class == "" || card.Class == "NEUTRAL" {
+			continue
+		}
+		if class != "" && class != card.Class {
+			return ""
+		}
+		class = card.Class
+	}
+	return class
+}
@@ -0,0 +1,92 @@
+package deckstrings_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeckList(t *testing.T) {
+	text := `
+2x Fireball
+1 Alexstrasza
+polymorph x2
+- 2 x Water Elemental (4)
+(3) Arcane Intellect
+Flamestrike ×1
+`
+
+	deck, unresolved := ParseDeckList(text, testCardDB(t))
+	assert.Nil(t, unresolved)
+	assert.Equal(t, Deck{
+		Format: FormatWild,
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{77, 2}, {315, 2}, {395, 2}, {555, 1}, {581, 1}, {1004, 1}},
+	}, deck)
+}
+
+func TestParseDeckListClipboard(t *testing.T) {
+	text := `### Freeze Mage
+# Class: Mage
+# Format: Standard
+# Year of the Pegasus
+#
+# 2x (4) Fireball
+# 1x (9) Alexstrasza
+#
+AAECAf0EAsUE7QUAAA==
+#
+# To use this deck, copy it to your clipboard and create a new deck in Hearthstone`
+
+	deck, unresolved := ParseDeckList(text, testCardDB(t))
+	assert.Nil(t, unresolved)
+	assert.Equal(t, Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{315, 2}, {581, 1}},
+	}, deck)
+}
+
+func TestParseDeckListUnresolved(t *testing.T) {
+	text := "2x Fireball\n1x Leeroy Jenkins\n0 Frostbolt\n2x Fiery War Axe"
+
+	deck, unresolved := ParseDeckList(text, testCardDB(t))
+	assert.Equal(t, []UnresolvedLine{
+		{Line: 2, Text: "1x Leeroy Jenkins", Reason: "unknown card"},
+		{Line: 3, Text: "0 Frostbolt", Reason: "invalid count"},
+	}, unresolved)
+	assert.Equal(t, `line 2: unknown card: "1x Leeroy Jenkins"`, unresolved[0].String())
+
+	// Mage and Warrior cards, so no hero.
+	assert.Empty(t, deck.Heroes)
+	assert.Equal(t, [][2]uint64{{315, 2}, {401, 2}}, deck.Cards)
+}
+
+func TestParseDeckListLocalized(t *testing.T) {
+	file, err := os.Open("testdata/cards.all.json")
+	require.Nil(t, err)
+	defer file.Close()
+
+	db, err := LoadCardDB(file)
+	require.Nil(t, err)
+
+	text := `### Feuermagier
+# Klasse: Magier
+# Format: Wild
+#
+# 2x (4) Feuerball
+# 2x (4) Métamorphose
+# 1x (9) Alexstrasza
+#`
+
+	deck, unresolved := ParseDeckList(text, db)
+	assert.Nil(t, unresolved)
+	assert.Equal(t, Deck{
+		Format: FormatWild,
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{77, 2}, {315, 2}, {581, 1}},
+	}, deck)
+}
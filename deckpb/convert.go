@@ -0,0 +1,60 @@
+// Package deckpb provides protocol buffer messages for Hearthstone decks, as
+// defined in deck.proto, along with converters to and from deckstrings.Deck.
+package deckpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative deck.proto
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/schmich/deckstrings"
+)
+
+// ToProto converts a deck to its protocol buffer representation. Returns an
+// error if the deck's format does not fit in the Format enum, which is 32
+// bits wide.
+func ToProto(deck deckstrings.Deck) (*Deck, error) {
+	if deck.Format > math.MaxInt32 {
+		return nil, errors.Errorf("deck proto: format %d out of range", deck.Format)
+	}
+
+	message := &Deck{
+		Format:  Format(deck.Format),
+		Heroes:  append([]uint64(nil), deck.Heroes...),
+		Cards:   make([]*Card, 0, len(deck.Cards)),
+		Version: deck.Version,
+	}
+
+	for _, card := range deck.Cards {
+		message.Cards = append(message.Cards, &Card{DbfId: card[0], Count: card[1]})
+	}
+
+	return message, nil
+}
+
+// FromProto converts a protocol buffer message to a deck. Sideboards are not
+// yet represented by deckstrings.Deck, so a message with sideboards results
+// in an error rather than silently dropping cards.
+func FromProto(message *Deck) (deckstrings.Deck, error) {
+	if message == nil {
+		return deckstrings.Deck{}, errors.New("deck proto: nil message")
+	}
+
+	if len(message.Sideboards) > 0 {
+		return deckstrings.Deck{}, errors.New("deck proto: sideboards are not supported")
+	}
+
+	deck := deckstrings.Deck{
+		Format:  deckstrings.Format(message.Format),
+		Heroes:  append([]uint64(nil), message.Heroes...),
+		Cards:   make([][2]uint64, 0, len(message.Cards)),
+		Version: message.Version,
+	}
+
+	for _, card := range message.Cards {
+		deck.Cards = append(deck.Cards, [2]uint64{card.GetDbfId(), card.GetCount()})
+	}
+
+	return deck, nil
+}
@@ -0,0 +1,81 @@
+package deckpb_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/deckpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	deck, err := deckstrings.Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+
+	message, err := deckpb.ToProto(deck)
+	assert.Nil(t, err)
+	assert.Equal(t, deckpb.Format_FORMAT_STANDARD, message.Format)
+	assert.Equal(t, len(deck.Cards), len(message.Cards))
+
+	data, err := proto.Marshal(message)
+	assert.Nil(t, err)
+
+	var decoded deckpb.Deck
+	assert.Nil(t, proto.Unmarshal(data, &decoded))
+
+	result, err := deckpb.FromProto(&decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, result)
+}
+
+func TestUnknownFormat(t *testing.T) {
+	deck := deckstrings.Deck{Format: 9, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 3}}}
+	message, err := deckpb.ToProto(deck)
+	assert.Nil(t, err)
+	result, err := deckpb.FromProto(message)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, result)
+}
+
+func TestToProtoFormatOutOfRange(t *testing.T) {
+	deck := deckstrings.Deck{Format: math.MaxInt32, Heroes: []uint64{7}}
+	message, err := deckpb.ToProto(deck)
+	assert.Nil(t, err)
+	assert.Equal(t, deckpb.Format(math.MaxInt32), message.Format)
+
+	deck.Format = math.MaxInt32 + 1
+	_, err = deckpb.ToProto(deck)
+	assert.EqualError(t, err, "deck proto: format 2147483648 out of range")
+
+	deck.Format = math.MaxUint64
+	_, err = deckpb.ToProto(deck)
+	assert.NotNil(t, err)
+}
+
+func TestFromProtoSideboards(t *testing.T) {
+	message := &deckpb.Deck{
+		Format:     deckpb.Format_FORMAT_WILD,
+		Heroes:     []uint64{7},
+		Sideboards: []*deckpb.Sideboard{{OwnerDbfId: 90749, Cards: []*deckpb.Card{{DbfId: 1, Count: 1}}}},
+	}
+	_, err := deckpb.FromProto(message)
+	assert.NotNil(t, err)
+}
+
+func TestFromProtoNil(t *testing.T) {
+	_, err := deckpb.FromProto(nil)
+	assert.NotNil(t, err)
+}
+
+func TestRoundTripVersion(t *testing.T) {
+	deck := deckstrings.Deck{Format: deckstrings.FormatWild, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}, Version: 2}
+	message, err := deckpb.ToProto(deck)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), message.GetVersion())
+
+	result, err := deckpb.FromProto(message)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, result)
+}
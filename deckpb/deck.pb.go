@@ -0,0 +1,407 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: deck.proto
+
+package deckpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Format is the game format of a deck. Values match the format byte
+// encoded in deckstrings.
+type Format int32
+
+const (
+	Format_FORMAT_UNKNOWN  Format = 0
+	Format_FORMAT_WILD     Format = 1
+	Format_FORMAT_STANDARD Format = 2
+	Format_FORMAT_CLASSIC  Format = 3
+	Format_FORMAT_TWIST    Format = 4
+)
+
+// Enum value maps for Format.
+var (
+	Format_name = map[int32]string{
+		0: "FORMAT_UNKNOWN",
+		1: "FORMAT_WILD",
+		2: "FORMAT_STANDARD",
+		3: "FORMAT_CLASSIC",
+		4: "FORMAT_TWIST",
+	}
+	Format_value = map[string]int32{
+		"FORMAT_UNKNOWN":  0,
+		"FORMAT_WILD":     1,
+		"FORMAT_STANDARD": 2,
+		"FORMAT_CLASSIC":  3,
+		"FORMAT_TWIST":    4,
+	}
+)
+
+func (x Format) Enum() *Format {
+	p := new(Format)
+	*p = x
+	return p
+}
+
+func (x Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_deck_proto_enumTypes[0].Descriptor()
+}
+
+func (Format) Type() protoreflect.EnumType {
+	return &file_deck_proto_enumTypes[0]
+}
+
+func (x Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Format.Descriptor instead.
+func (Format) EnumDescriptor() ([]byte, []int) {
+	return file_deck_proto_rawDescGZIP(), []int{0}
+}
+
+// Card is a card in a deck by DBF ID along with the number of copies.
+type Card struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DbfId uint64 `protobuf:"varint,1,opt,name=dbf_id,json=dbfId,proto3" json:"dbf_id,omitempty"`
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deck_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_deck_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_deck_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Card) GetDbfId() uint64 {
+	if x != nil {
+		return x.DbfId
+	}
+	return 0
+}
+
+func (x *Card) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Sideboard is the set of cards attached to a card in the main deck, such as
+// E.T.C., Band Manager.
+type Sideboard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerDbfId uint64  `protobuf:"varint,1,opt,name=owner_dbf_id,json=ownerDbfId,proto3" json:"owner_dbf_id,omitempty"`
+	Cards      []*Card `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+}
+
+func (x *Sideboard) Reset() {
+	*x = Sideboard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deck_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sideboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sideboard) ProtoMessage() {}
+
+func (x *Sideboard) ProtoReflect() protoreflect.Message {
+	mi := &file_deck_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sideboard.ProtoReflect.Descriptor instead.
+func (*Sideboard) Descriptor() ([]byte, []int) {
+	return file_deck_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Sideboard) GetOwnerDbfId() uint64 {
+	if x != nil {
+		return x.OwnerDbfId
+	}
+	return 0
+}
+
+func (x *Sideboard) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+// Deck is a Hearthstone deck.
+type Deck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format     Format       `protobuf:"varint,1,opt,name=format,proto3,enum=deckstrings.Format" json:"format,omitempty"`
+	Heroes     []uint64     `protobuf:"varint,2,rep,packed,name=heroes,proto3" json:"heroes,omitempty"`
+	Cards      []*Card      `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`
+	Sideboards []*Sideboard `protobuf:"bytes,4,rep,name=sideboards,proto3" json:"sideboards,omitempty"`
+	// Version is the deckstring encoding version. 0 means the current
+	// version.
+	Version uint64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Deck) Reset() {
+	*x = Deck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_deck_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Deck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deck) ProtoMessage() {}
+
+func (x *Deck) ProtoReflect() protoreflect.Message {
+	mi := &file_deck_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deck.ProtoReflect.Descriptor instead.
+func (*Deck) Descriptor() ([]byte, []int) {
+	return file_deck_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Deck) GetFormat() Format {
+	if x != nil {
+		return x.Format
+	}
+	return Format_FORMAT_UNKNOWN
+}
+
+func (x *Deck) GetHeroes() []uint64 {
+	if x != nil {
+		return x.Heroes
+	}
+	return nil
+}
+
+func (x *Deck) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *Deck) GetSideboards() []*Sideboard {
+	if x != nil {
+		return x.Sideboards
+	}
+	return nil
+}
+
+func (x *Deck) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_deck_proto protoreflect.FileDescriptor
+
+var file_deck_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x64, 0x65, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x64, 0x65,
+	0x63, 0x6b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x33, 0x0a, 0x04, 0x43, 0x61, 0x72,
+	0x64, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x62, 0x66, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x64, 0x62, 0x66, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x56,
+	0x0a, 0x09, 0x53, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x5f, 0x64, 0x62, 0x66, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x44, 0x62, 0x66, 0x49, 0x64, 0x12, 0x27, 0x0a,
+	0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64,
+	0x65, 0x63, 0x6b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52,
+	0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x04, 0x44, 0x65, 0x63, 0x6b, 0x12,
+	0x2b, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x64, 0x65, 0x63, 0x6b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65,
+	0x72, 0x6f, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x65, 0x63, 0x6b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x73, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x36, 0x0a,
+	0x0a, 0x73, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x64, 0x65, 0x63, 0x6b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x2e,
+	0x53, 0x69, 0x64, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0a, 0x73, 0x69, 0x64, 0x65, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x2a,
+	0x68, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x4f, 0x52,
+	0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x57, 0x49, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x13,
+	0x0a, 0x0f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x41, 0x52,
+	0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x4c,
+	0x41, 0x53, 0x53, 0x49, 0x43, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x4f, 0x52, 0x4d, 0x41,
+	0x54, 0x5f, 0x54, 0x57, 0x49, 0x53, 0x54, 0x10, 0x04, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x68, 0x6d, 0x69, 0x63, 0x68, 0x2f,
+	0x64, 0x65, 0x63, 0x6b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x64, 0x65, 0x63, 0x6b,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_deck_proto_rawDescOnce sync.Once
+	file_deck_proto_rawDescData = file_deck_proto_rawDesc
+)
+
+func file_deck_proto_rawDescGZIP() []byte {
+	file_deck_proto_rawDescOnce.Do(func() {
+		file_deck_proto_rawDescData = protoimpl.X.CompressGZIP(file_deck_proto_rawDescData)
+	})
+	return file_deck_proto_rawDescData
+}
+
+var file_deck_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_deck_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_deck_proto_goTypes = []any{
+	(Format)(0),       // 0: deckstrings.Format
+	(*Card)(nil),      // 1: deckstrings.Card
+	(*Sideboard)(nil), // 2: deckstrings.Sideboard
+	(*Deck)(nil),      // 3: deckstrings.Deck
+}
+var file_deck_proto_depIdxs = []int32{
+	1, // 0: deckstrings.Sideboard.cards:type_name -> deckstrings.Card
+	0, // 1: deckstrings.Deck.format:type_name -> deckstrings.Format
+	1, // 2: deckstrings.Deck.cards:type_name -> deckstrings.Card
+	2, // 3: deckstrings.Deck.sideboards:type_name -> deckstrings.Sideboard
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_deck_proto_init() }
+func file_deck_proto_init() {
+	if File_deck_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_deck_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Card); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deck_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Sideboard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_deck_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Deck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_deck_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_deck_proto_goTypes,
+		DependencyIndexes: file_deck_proto_depIdxs,
+		EnumInfos:         file_deck_proto_enumTypes,
+		MessageInfos:      file_deck_proto_msgTypes,
+	}.Build()
+	File_deck_proto = out.File
+	file_deck_proto_rawDesc = nil
+	file_deck_proto_goTypes = nil
+	file_deck_proto_depIdxs = nil
+}
@@ -0,0 +1,78 @@
+// Package deckqr renders Hearthstone deckstrings as QR codes, so decks can be
+// shared on stream overlays, slides, and printed material and scanned with a
+// phone.
+//
+// QR codes hold the deckstring as is, using medium error correction, so any
+// QR scanner yields a deckstring that can be pasted into the game client.
+// Decoding QR codes from images is not supported by this package.
+//
+// Like deckimage, deckqr is a module of its own, so go-qrcode is required
+// only by programs that import it.
+package deckqr
+
+import (
+	"image"
+
+	"github.com/pkg/errors"
+	"github.com/schmich/deckstrings"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Image returns a QR code for deckstring as a square black and white image
+// size pixels wide, including a quiet zone border. If size is negative, each
+// QR module is -size pixels wide instead.
+func Image(deckstring string, size int) (image.Image, error) {
+	code, err := newCode(deckstring)
+	if err != nil {
+		return nil, err
+	}
+	return code.Image(size), nil
+}
+
+// PNG is like Image but returns the QR code encoded as a PNG.
+func PNG(deckstring string, size int) ([]byte, error) {
+	code, err := newCode(deckstring)
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(size)
+}
+
+// EncodePNG encodes a deck with deckstrings.Encode and returns its QR code
+// like PNG.
+func EncodePNG(deck deckstrings.Deck, size int, opts ...deckstrings.EncodeOption) ([]byte, error) {
+	deckstring, err := deckstrings.Encode(deck, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return PNG(deckstring, size)
+}
+
+// String returns a QR code for deckstring drawn with Unicode block characters,
+// two modules per character row, for terminals and chat. Modules are drawn
+// light on dark, for terminals with dark backgrounds.
+func String(deckstring string) (string, error) {
+	code, err := newCode(deckstring)
+	if err != nil {
+		return "", err
+	}
+	return code.ToSmallString(false), nil
+}
+
+// Bitmap returns the modules of a QR code for deckstring, including the quiet
+// zone, with true for dark modules, for callers drawing codes themselves.
+func Bitmap(deckstring string) ([][]bool, error) {
+	code, err := newCode(deckstring)
+	if err != nil {
+		return nil, err
+	}
+	return code.Bitmap(), nil
+}
+
+func newCode(deckstring string) (*qrcode.QRCode, error) {
+	code, err := qrcode.New(deckstring, qrcode.Medium)
+	if err != nil {
+		return nil, errors.Wrap(err, "deck QR code")
+	}
+	return code, nil
+}
@@ -0,0 +1,78 @@
+package deckqr_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/deckqr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDeckstring = "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+func TestImage(t *testing.T) {
+	img, err := deckqr.Image(testDeckstring, 256)
+	require.Nil(t, err)
+	assert.Equal(t, 256, img.Bounds().Dx())
+	assert.Equal(t, 256, img.Bounds().Dy())
+
+	// The quiet zone is light.
+	r, g, b, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+}
+
+func TestPNG(t *testing.T) {
+	data, err := deckqr.PNG(testDeckstring, -4)
+	require.Nil(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.Nil(t, err)
+
+	bitmap, err := deckqr.Bitmap(testDeckstring)
+	require.Nil(t, err)
+	assert.Equal(t, len(bitmap)*4, img.Bounds().Dx())
+}
+
+func TestEncodePNG(t *testing.T) {
+	deck := deckstrings.MustDecode(testDeckstring)
+
+	data, err := deckqr.EncodePNG(deck, 128)
+	require.Nil(t, err)
+	expected, err := deckqr.PNG(deckstrings.MustEncode(deck), 128)
+	require.Nil(t, err)
+	assert.Equal(t, expected, data)
+
+	_, err = deckqr.EncodePNG(deckstrings.Deck{Cards: [][2]uint64{{1, 0}}}, 128)
+	assert.NotNil(t, err)
+}
+
+func TestBitmap(t *testing.T) {
+	bitmap, err := deckqr.Bitmap(testDeckstring)
+	require.Nil(t, err)
+
+	// Codes are square, and a finder pattern sits inside the quiet zone in the
+	// top-left corner.
+	for _, row := range bitmap {
+		assert.Len(t, row, len(bitmap))
+	}
+	assert.False(t, bitmap[0][0])
+	assert.True(t, bitmap[4][4])
+}
+
+func TestString(t *testing.T) {
+	text, err := deckqr.String(testDeckstring)
+	require.Nil(t, err)
+
+	bitmap, err := deckqr.Bitmap(testDeckstring)
+	require.Nil(t, err)
+	assert.Equal(t, (len(bitmap)+1)/2, strings.Count(text, "\n"))
+}
+
+func TestTooLong(t *testing.T) {
+	_, err := deckqr.PNG(strings.Repeat("A", 4000), 256)
+	assert.Contains(t, err.Error(), "deck QR code")
+}
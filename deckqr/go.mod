@@ -0,0 +1,18 @@
+module github.com/schmich/deckstrings/deckqr
+
+go 1.23.0
+
+require (
+	github.com/pkg/errors v0.8.0
+	github.com/schmich/deckstrings v0.0.0-20261017031024-ab6b3d15600e
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/schmich/deckstrings => ../
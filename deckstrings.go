@@ -10,12 +10,14 @@
 package deckstrings
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -25,15 +27,50 @@ import (
 // package include this version.
 const Version = 1
 
-// The game format for which the deck was built. Wild and Standard are the current
-// Hearthstone game formats.
+// The game format for which the deck was built. Wild, Standard, Classic, and
+// Twist are the current Hearthstone game formats.
 type Format uint64
 
 const (
 	FormatWild     Format = 1
 	FormatStandard Format = 2
+	FormatClassic  Format = 3
+	FormatTwist    Format = 4
 )
 
+var formatNames = map[Format]string{
+	FormatWild:     "Wild",
+	FormatStandard: "Standard",
+	FormatClassic:  "Classic",
+	FormatTwist:    "Twist",
+}
+
+// String returns the display name of the format (e.g. "Standard"). Formats
+// unknown to this package are rendered as "Format(n)".
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Format(%d)", uint64(f))
+}
+
+// Known reports whether the format is one of the formats defined by this package.
+func (f Format) Known() bool {
+	_, ok := formatNames[f]
+	return ok
+}
+
+// formatByName returns the format with the given display name, compared
+// case-insensitively.
+func formatByName(name string) (Format, bool) {
+	for format, formatName := range formatNames {
+		if strings.EqualFold(formatName, name) {
+			return format, true
+		}
+	}
+	return 0, false
+}
+
 // Deck represents a Hearthstone deck with its associated game format, hero,
 // and card inventory.
 //
@@ -57,95 +94,65 @@ const (
 // seen in Hearthstone decks. The count of cards will typically sum to 30, but a
 // deckstring can encode an arbitrary number of cards.
 //
+// The Version field is the deckstring encoding version the deck was decoded
+// from, if not the current Version, so decks re-emitted with Encode keep their
+// original version. It is 0 for decks decoded from the current Version, for
+// newer versions decoded with DecodeNewerVersions, and for decks built by
+// hand, all of which Encode treats as Version.
+//
 // See HearthstoneJSON for hero and card metadata using DBF IDs:
 // https://hearthstonejson.com/
 type Deck struct {
-	Format Format
-	Heroes []uint64
-	Cards  [][2]uint64
+	Format  Format
+	Heroes  []uint64
+	Cards   [][2]uint64
+	Version uint64
 }
 
 // Decode a deckstring into a Hearthstone deck.
 //
 // Decodings are canonical: the resulting deck's Heroes and Cards fields are
-// ordered by DBF ID ascending.
+// ordered by DBF ID ascending, and cards listed more than once in the
+// deckstring are merged by summing their counts. See KeepDuplicates.
 //
 // Returns an error if the string is not base64 encoded, if the deckstring version
 // is not supported, or if the general format is invalid. See the Deck type for
 // details about possible values and ranges for format, heroes, and cards.
-func Decode(deckstring string) (deck Deck, err error) {
+//
+// Options can be given to further restrict what is accepted, e.g. RequireFormat.
+func Decode(deckstring string, opts ...DecodeOption) (deck Deck, err error) {
 	defer func() {
 		if err != nil {
 			err = errors.Wrap(err, "deckstring decode")
 		}
 	}()
 
-	reader := bufio.NewReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(deckstring)))
-	varint := &varintReader{reader}
-
-	header := [4]uint64{}
-	if err = varint.ReadMany(header[:]); err != nil {
-		return Deck{}, err
-	}
-
-	if reserved := header[0]; reserved != 0 {
-		return Deck{}, fmt.Errorf("unexpected reserved byte: %d", reserved)
-	}
-
-	if version := header[1]; version != Version {
-		return Deck{}, fmt.Errorf("unsupported version: %d", version)
-	}
-
-	format, length := header[2], header[3]
+	deck, _, err = decode(newPayloadReader(deckstring), newDecodeOptions(opts))
+	return deck, err
+}
 
-	heroes := make([]uint64, length)
-	for i := uint64(0); i < length; i++ {
-		hero, err := varint.Read()
+// DecodeBytes is like Decode but takes the deckstring as a byte slice, such
+// as an HTTP body or database value, avoiding a conversion to string.
+func DecodeBytes(deckstring []byte, opts ...DecodeOption) (deck Deck, err error) {
+	defer func() {
 		if err != nil {
-			return Deck{}, err
-		}
-
-		heroes[i] = hero
-	}
-
-	// Sort heroes.
-	sort.Slice(heroes, func(i, j int) bool { return heroes[i] < heroes[j] })
-
-	cards := make([][2]uint64, 0, 30)
-
-	for group := 1; group <= 3; group++ {
-		var err error
-		var length uint64
-		if length, err = varint.Read(); err != nil {
-			return Deck{}, err
+			err = errors.Wrap(err, "deckstring decode")
 		}
+	}()
 
-		for i := uint64(0); i < length; i++ {
-			dbfID, err := varint.Read()
-			if err != nil {
-				return Deck{}, err
-			}
-
-			count := uint64(group)
-			if group >= 3 {
-				if count, err = varint.Read(); err != nil {
-					return Deck{}, err
-				}
-			}
+	deck, _, err = decode(newPayloadBytesReader(deckstring), newDecodeOptions(opts))
+	return deck, err
+}
 
-			card := [2]uint64{dbfID, count}
-			cards = append(cards, card)
-		}
+// MustDecode is like Decode but panics if the deckstring cannot be decoded. It
+// simplifies initialization of variables holding known-good deckstrings and
+// use in tests and examples.
+func MustDecode(deckstring string, opts ...DecodeOption) Deck {
+	deck, err := Decode(deckstring, opts...)
+	if err != nil {
+		panic(`deckstrings: MustDecode(` + strconv.Quote(deckstring) + `): ` + err.Error())
 	}
-
-	// Sort cards by DBF ID.
-	sort.Slice(cards, func(i, j int) bool { return cards[i][0] < cards[j][0] })
-
-	return Deck{
-		Format: Format(format),
-		Heroes: heroes,
-		Cards:  cards,
-	}, nil
+	return deck
 }
 
 // Encode a Hearthstone deck into a deckstring using base64.StdEncoding.
@@ -153,9 +160,12 @@ func Decode(deckstring string) (deck Deck, err error) {
 // Encodings are canonical: the deck's Heroes and Cards fields are encoded
 // in ascending DBF ID order.
 //
+// Cards listed more than once are merged by summing their counts unless
+// another policy is given with OnDuplicates.
+//
 // Returns an error if any card count is 0. See the Deck type for details
 // about possible values and ranges for format, heroes, and cards.
-func Encode(deck Deck) (deckstring string, err error) {
+func Encode(deck Deck, opts ...EncodeOption) (deckstring string, err error) {
 	defer func() {
 		if err != nil {
 			err = errors.Wrap(err, "deckstring encode")
@@ -163,27 +173,80 @@ func Encode(deck Deck) (deckstring string, err error) {
 	}()
 
 	var buf bytes.Buffer
-	writer := base64.NewEncoder(base64.StdEncoding, &buf)
-	varint := &varintWriter{writer}
+	if err = encode(&buf, deck, newEncodeOptions(opts)); err != nil {
+		return "", err
+	}
 
-	values := []uint64{
-		0,       // Reserved. Must be zero.
-		Version, // Deckstring encoding version.
-		uint64(deck.Format),
-		uint64(len(deck.Heroes)),
+	return buf.String(), nil
+}
+
+// EncodeTo is like Encode but writes the deckstring directly to writer,
+// returning the number of bytes written. Nothing is written if the deck
+// cannot be encoded.
+func EncodeTo(writer io.Writer, deck Deck, opts ...EncodeOption) (n int, err error) {
+	counter := &countingWriter{writer: writer}
+	if err = encode(counter, deck, newEncodeOptions(opts)); err != nil {
+		return counter.n, errors.Wrap(err, "deckstring encode")
 	}
+	return counter.n, nil
+}
 
-	if err = varint.WriteMany(values); err != nil {
-		return "", err
+// encode validates the deck and writes its base64-encoded deckstring to
+// output, reporting the result to the registered Metrics. Validation happens
+// before anything is written, so errors leave output untouched unless output
+// itself fails.
+func encode(output io.Writer, deck Deck, options *encodeOptions) (err error) {
+	if metrics := loadMetrics(); metrics != nil {
+		start := time.Now()
+		defer func() { metrics.ObserveEncode(time.Since(start), err) }()
 	}
 
-	// Sort heroes.
-	heroes := make([]uint64, len(deck.Heroes))
-	copy(heroes, deck.Heroes)
-	sort.Slice(heroes, func(i, j int) bool { return heroes[i] < heroes[j] })
+	writer := base64.NewEncoder(base64.StdEncoding, output)
+	if options.compress {
+		var payload bytes.Buffer
+		if err := encodePayload(&payload, deck, options); err != nil {
+			return err
+		}
+		if _, err := writer.Write(compress(payload.Bytes())); err != nil {
+			return err
+		}
+	} else if err := encodePayload(writer, deck, options); err != nil {
+		return err
+	}
+	return writer.Close()
+}
 
-	if err = varint.WriteMany(heroes); err != nil {
-		return "", err
+// encodePayload is like encode but writes the raw payload without base64
+// encoding, for embedding in containers.
+func encodePayload(writer io.Writer, deck Deck, options *encodeOptions) error {
+	// Unless pinned, encode with the version the deck was decoded from.
+	if !options.pinVersion {
+		options.version = deck.version()
+	}
+
+	codec, ok := lookupCodec(options.version)
+	if !ok {
+		return fmt.Errorf("unsupported version: %d", options.version)
+	}
+	return codec.encode(writer, deck, options)
+}
+
+// encodeVersionOne writes the payload of a version 1 deckstring.
+func encodeVersionOne(writer io.Writer, deck Deck, options *encodeOptions) error {
+	switch options.duplicates {
+	case DuplicatesMerge:
+		deck.Cards = Canonicalize(deck).Cards
+	case DuplicatesError:
+		seen := make(map[uint64]bool, len(deck.Cards))
+		for _, card := range deck.Cards {
+			if seen[card[0]] {
+				return fmt.Errorf("duplicate DBF ID %d", card[0])
+			}
+			seen[card[0]] = true
+		}
+	case DuplicatesKeep:
+	default:
+		return fmt.Errorf("invalid duplicate policy: %d", options.duplicates)
 	}
 
 	// Gather cards into groups based on their count in the deck.
@@ -192,7 +255,7 @@ func Encode(deck Deck) (deckstring string, err error) {
 	for _, card := range deck.Cards {
 		dbfID, count := card[0], card[1]
 		if count < 1 {
-			return "", fmt.Errorf("invalid card count for DBF ID %d", dbfID)
+			return fmt.Errorf("invalid card count for DBF ID %d", dbfID)
 		}
 
 		groupID := 3
@@ -200,46 +263,114 @@ func Encode(deck Deck) (deckstring string, err error) {
 			groupID = int(count)
 		}
 
-		if _, ok := groups[groupID]; !ok {
-			groups[groupID] = [][2]uint64{card}
-		} else {
-			groups[groupID] = append(groups[groupID], card)
+		if assigned, ok := options.groups[dbfID]; ok {
+			if assigned < 1 || assigned > 3 || (assigned < 3 && uint64(assigned) != count) {
+				return fmt.Errorf("cannot assign DBF ID %d with count %d to group %d", dbfID, count, assigned)
+			}
+			groupID = assigned
 		}
+
+		groups[groupID] = append(groups[groupID], card)
+	}
+
+	varint := &varintWriter{writer}
+
+	values := []uint64{
+		options.reserved, // Reserved. Zero unless set with EncodeReserved.
+		options.version,  // Deckstring encoding version.
+		uint64(deck.Format),
+		uint64(len(deck.Heroes)),
+	}
+
+	if err := varint.WriteMany(values); err != nil {
+		return err
+	}
+
+	// Sort heroes.
+	heroes := make([]uint64, len(deck.Heroes))
+	copy(heroes, deck.Heroes)
+	sort.Slice(heroes, func(i, j int) bool { return heroes[i] < heroes[j] })
+
+	if err := varint.WriteMany(heroes); err != nil {
+		return err
 	}
 
 	for groupID := 1; groupID <= 3; groupID++ {
-		var ok bool
-		var group [][2]uint64
-		if group, ok = groups[groupID]; !ok {
-			group = [][2]uint64{}
-		}
+		group := groups[groupID]
 
 		// Sort group by card DBF ID.
 		sort.Slice(group, func(i, j int) bool { return group[i][0] < group[j][0] })
 
-		if err = varint.Write(uint64(len(group))); err != nil {
-			return "", err
+		if err := varint.Write(uint64(len(group))); err != nil {
+			return err
 		}
 
 		for _, card := range group {
 			dbfID, count := card[0], card[1]
-			if err = varint.Write(dbfID); err != nil {
-				return "", err
+			if err := varint.Write(dbfID); err != nil {
+				return err
 			}
 
 			// For cards with unusual counts (e.g. not 1x or 2x),
 			// we write an explicit count as well.
 			if groupID == 3 {
-				if err = varint.Write(count); err != nil {
-					return "", err
+				if err := varint.Write(count); err != nil {
+					return err
 				}
 			}
 		}
 	}
 
-	if err = writer.Close(); err != nil {
-		return "", err
+	return nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	writer io.Writer
+	n      int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.n += n
+	return n, err
+}
+
+// MustEncode is like Encode but panics if the deck cannot be encoded.
+func MustEncode(deck Deck, opts ...EncodeOption) string {
+	deckstring, err := Encode(deck, opts...)
+	if err != nil {
+		panic(`deckstrings: MustEncode: ` + err.Error())
 	}
+	return deckstring
+}
 
-	return buf.String(), nil
+// Normalize decodes a deckstring and re-encodes it in canonical form, reporting
+// whether the canonical deckstring differs from the input. Two deckstrings for
+// the same deck normalize to the same string, making this suitable as a
+// deduplication step before storing deckstrings.
+//
+// Canonical form orders heroes and cards by DBF ID and merges duplicate card
+// entries. Trailing data after the card groups, such as the sideboards written
+// by newer clients, is kept byte for byte since it is not decoded.
+func Normalize(deckstring string, opts ...DecodeOption) (normalized string, changed bool, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring normalize")
+		}
+	}()
+
+	deck, info, err := decode(newPayloadReader(deckstring), newDecodeOptions(opts))
+	if err != nil {
+		return "", false, err
+	}
+
+	var payload bytes.Buffer
+	if err := encodePayload(&payload, Canonicalize(deck), newEncodeOptions(nil)); err != nil {
+		return "", false, err
+	}
+	payload.Write(info.Trailing)
+
+	normalized = base64.StdEncoding.EncodeToString(payload.Bytes())
+	return normalized, normalized != deckstring, nil
 }
@@ -1,6 +1,8 @@
 package deckstrings_test
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"testing"
 
@@ -87,6 +89,147 @@ func TestEncodeInvalidCount(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestEncodeDuplicatesMerge(t *testing.T) {
+	deck := Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{5, 1}, {9, 1}, {5, 1}}}
+
+	deckstring, err := Encode(deck)
+	assert.Nil(t, err)
+
+	canonical, err := Encode(Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{5, 2}, {9, 1}}})
+	assert.Nil(t, err)
+	assert.Equal(t, canonical, deckstring)
+
+	decoded, err := Decode(deckstring, KeepDuplicates())
+	assert.Nil(t, err)
+	assert.Equal(t, Canonicalize(deck), decoded)
+}
+
+func TestEncodeDuplicatesError(t *testing.T) {
+	_, err := Encode(Deck{Cards: [][2]uint64{{5, 1}, {5, 2}}}, OnDuplicates(DuplicatesError))
+	assert.NotNil(t, err)
+
+	_, err = Encode(Deck{Cards: [][2]uint64{{5, 1}, {6, 2}}}, OnDuplicates(DuplicatesError))
+	assert.Nil(t, err)
+}
+
+func TestEncodeDuplicatesKeep(t *testing.T) {
+	deck := Deck{Heroes: []uint64{7}, Format: FormatWild, Cards: [][2]uint64{{5, 1}, {5, 2}, {5, 3}}}
+
+	deckstring, err := Encode(deck, OnDuplicates(DuplicatesKeep))
+	assert.Nil(t, err)
+	assert.Equal(t, "AAEBAQcBBQEFAQUD", deckstring)
+
+	decoded, err := Decode(deckstring, KeepDuplicates())
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+}
+
+func TestEncodeInvalidDuplicatePolicy(t *testing.T) {
+	_, err := Encode(Deck{}, OnDuplicates(DuplicatePolicy(9)))
+	assert.NotNil(t, err)
+}
+
+func TestEncodeAssignGroups(t *testing.T) {
+	// Card 5 with a count of 1 listed in the explicit count group.
+	deckstring := "AAEBAQcAAAEFAQ=="
+	deck, info, err := DecodeWithInfo(deckstring)
+	assert.Nil(t, err)
+
+	canonical, err := Encode(deck)
+	assert.Nil(t, err)
+	assert.NotEqual(t, deckstring, canonical)
+
+	groups := make(map[uint64]int)
+	for _, entry := range info.Entries {
+		groups[entry.DBFID] = entry.Group
+	}
+
+	encoded, err := Encode(deck, AssignGroups(groups))
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring, encoded)
+}
+
+func TestEncodeAssignGroupsInvalid(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{5, 1}, {6, 3}}}
+
+	_, err := Encode(deck, AssignGroups(map[uint64]int{5: 2}))
+	assert.NotNil(t, err)
+
+	_, err = Encode(deck, AssignGroups(map[uint64]int{6: 1}))
+	assert.NotNil(t, err)
+
+	_, err = Encode(deck, AssignGroups(map[uint64]int{5: 4}))
+	assert.NotNil(t, err)
+
+	_, err = Encode(deck, AssignGroups(map[uint64]int{5: 1, 6: 3}))
+	assert.Nil(t, err)
+}
+
+func TestEncodeVersion(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck := MustDecode(deckstring)
+
+	encoded, err := Encode(deck, EncodeVersion(Version))
+	assert.Nil(t, err)
+	assert.Equal(t, MustEncode(deck), encoded)
+
+	for _, version := range []uint64{0, 2} {
+		_, err = Encode(deck, EncodeVersion(version))
+		assert.EqualError(t, err, fmt.Sprintf("deckstring encode: unsupported version: %d", version))
+	}
+}
+
+func TestEncodeDeckVersion(t *testing.T) {
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Equal(t, uint64(0), deck.Version)
+
+	// Decks keep the version they were decoded from.
+	deck.Version = 99
+	deckstring, err := Encode(deck)
+	assert.Nil(t, err)
+	decoded := MustDecode(deckstring)
+	assert.Equal(t, uint64(99), decoded.Version)
+	assert.Equal(t, deck, decoded)
+
+	// Pinning the version overrides the deck's version.
+	deckstring, err = Encode(deck, EncodeVersion(Version))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), MustDecode(deckstring).Version)
+
+	// Versions decoded on a best-effort basis are re-encoded as version 1.
+	deck = MustDecode("AAIBAQcAAAA=", DecodeNewerVersions())
+	assert.Equal(t, uint64(0), deck.Version)
+	deckstring, err = Encode(deck)
+	assert.Nil(t, err)
+	assert.Equal(t, "AAEBAQcAAAA=", deckstring)
+}
+
+func TestEncodeReserved(t *testing.T) {
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+
+	deckstring, err := Encode(deck, EncodeReserved(5))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring)
+	assert.EqualError(t, err, "deckstring decode: unexpected reserved byte: 5")
+
+	decoded, info, err := DecodeWithInfo(deckstring, AllowReserved())
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+	assert.Equal(t, uint64(5), info.Reserved)
+
+	// Registered codecs get the same treatment.
+	deckstring, err = Encode(deck, EncodeReserved(5), EncodeVersion(99))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring)
+	assert.EqualError(t, err, "deckstring decode: unexpected reserved byte: 5")
+
+	_, info, err = DecodeWithInfo(deckstring, AllowReserved())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), info.Reserved)
+}
+
 func TestDecodeUnsortedHeroes(t *testing.T) {
 	deckstring := "AAEAAgIBAAAA"
 	deck := Deck{Heroes: []uint64{1, 2}, Cards: [][2]uint64{}}
@@ -133,6 +276,120 @@ func TestDecodeUnexpectedEOF(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestNormalize(t *testing.T) {
+	normalized, changed, err := Normalize("AAEAAgIBAAAA")
+	assert.Nil(t, err)
+	assert.True(t, changed)
+
+	expected, err := Encode(Deck{Heroes: []uint64{1, 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, normalized)
+
+	again, changed, err := Normalize(normalized)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, normalized, again)
+}
+
+func TestNormalizeCanonical(t *testing.T) {
+	deckstring := "AAECAZICCPIF+Az5DK6rAuC7ApS9AsnHApnTAgtAX/4BxAbkCLS7Asu8As+8At2+AqDNAofOAgA="
+	normalized, changed, err := Normalize(deckstring)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, deckstring, normalized)
+}
+
+func TestNormalizeTrailing(t *testing.T) {
+	// Current clients end a deck without sideboards with a zero byte.
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAAA"
+	normalized, changed, err := Normalize(deckstring)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, deckstring, normalized)
+
+	// The sideboards are kept while the heroes are reordered.
+	_, info, err := DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAABAY0BHwAA")
+	assert.Nil(t, err)
+
+	payload := []byte{0, 1, 1, 2, 2, 1, 0, 0, 0}
+	payload = append(payload, info.Trailing...)
+	normalized, changed, err = Normalize(base64.StdEncoding.EncodeToString(payload))
+	assert.Nil(t, err)
+	assert.True(t, changed)
+
+	_, normalizedInfo, err := DecodeWithInfo(normalized)
+	assert.Nil(t, err)
+	assert.Equal(t, info.Trailing, normalizedInfo.Trailing)
+	assert.True(t, normalizedInfo.HasSideboards)
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	_, _, err := Normalize("AAIAAAAAAA==")
+	assert.NotNil(t, err)
+
+	_, _, err = Normalize("AAEBAAAAAA==", RequireFormat(FormatStandard))
+	assert.NotNil(t, err)
+}
+
+func TestDecodeBytes(t *testing.T) {
+	deckstring := "AAECAZICCPIF+Az5DK6rAuC7ApS9AsnHApnTAgtAX/4BxAbkCLS7Asu8As+8At2+AqDNAofOAgA="
+	expected, err := Decode(deckstring)
+	assert.Nil(t, err)
+
+	deck, err := DecodeBytes([]byte(deckstring))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, deck)
+
+	_, err = DecodeBytes([]byte("AAIAAAAAAA=="))
+	assert.NotNil(t, err)
+
+	_, err = DecodeBytes([]byte("AAEBAAAAAA=="), RequireFormat(FormatStandard))
+	assert.NotNil(t, err)
+}
+
+func TestMustDecode(t *testing.T) {
+	deck := MustDecode("AAEAAAAAAA==")
+	assert.Equal(t, Deck{Heroes: []uint64{}, Cards: [][2]uint64{}}, deck)
+
+	assert.Panics(t, func() { MustDecode("AAIAAAAAAA==") })
+	assert.Panics(t, func() { MustDecode("AAEBAAAAAA==", RequireFormat(FormatStandard)) })
+}
+
+func TestMustEncode(t *testing.T) {
+	assert.Equal(t, "AAEAAAAAAA==", MustEncode(Deck{}))
+	assert.Panics(t, func() { MustEncode(Deck{Cards: [][2]uint64{{1, 0}}}) })
+}
+
+func TestFormatString(t *testing.T) {
+	assert.Equal(t, "Wild", FormatWild.String())
+	assert.Equal(t, "Standard", FormatStandard.String())
+	assert.Equal(t, "Classic", FormatClassic.String())
+	assert.Equal(t, "Twist", FormatTwist.String())
+	assert.Equal(t, "Format(0)", Format(0).String())
+	assert.Equal(t, "Format(42)", fmt.Sprint(Format(42)))
+}
+
+func TestDecodeRequireKnownFormat(t *testing.T) {
+	_, err := Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=", RequireFormat())
+	assert.Nil(t, err)
+
+	_, err = Decode("AAEAAAAAAA==", RequireFormat())
+	assert.NotNil(t, err)
+
+	_, err = Decode("AAEqAAAAAA==", RequireFormat())
+	assert.NotNil(t, err)
+}
+
+func TestDecodeRequireFormatAllowlist(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	_, err := Decode(deckstring, RequireFormat(FormatWild, FormatStandard))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring, RequireFormat(FormatWild))
+	assert.NotNil(t, err)
+}
+
 func TestDeckstrings(t *testing.T) {
 	deckstrings := []string{
 		"AAEBAf0GAA/yAaIC3ALgBPcE+wWKBs4H2QexCMII2Q31DfoN9g4A",
@@ -207,15 +464,35 @@ func ExampleEncode() {
 func ExampleDecode_empty() {
 	deckstring := "AAEAAAAAAA=="
 	deck, err := deckstrings.Decode(deckstring)
-	fmt.Printf("%+v %v", deck, err)
+	fmt.Println(deck, err)
 	// Output:
-	// {Format:0 Heroes:[] Cards:[]} <nil>
+	// Format(0), 0 heroes, 0 cards (0 distinct) <nil>
 }
 
 func ExampleDecode() {
 	deckstring := "AAECAZICCPIF+Az5DK6rAuC7ApS9AsnHApnTAgtAX/4BxAbkCLS7Asu8As+8At2+AqDNAofOAgA="
 	deck, err := deckstrings.Decode(deckstring)
-	fmt.Printf("%+v %v", deck, err)
+	fmt.Println(deck, err)
+	fmt.Println(deck.Heroes, deck.Cards)
 	// Output:
-	// {Format:2 Heroes:[274] Cards:[[64 2] [95 2] [254 2] [754 1] [836 2] [1124 2] [1656 1] [1657 1] [38318 1] [40372 2] [40416 1] [40523 2] [40527 2] [40596 1] [40797 2] [41929 1] [42656 2] [42759 2] [43417 1]]} <nil>
+	// Standard, 1 hero, 30 cards (19 distinct) <nil>
+	// [274] [[64 2] [95 2] [254 2] [754 1] [836 2] [1124 2] [1656 1] [1657 1] [38318 1] [40372 2] [40416 1] [40523 2] [40527 2] [40596 1] [40797 2] [41929 1] [42656 2] [42759 2] [43417 1]]
+}
+
+func TestEncodeTo(t *testing.T) {
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+
+	var buf bytes.Buffer
+	n, err := EncodeTo(&buf, deck)
+	assert.Nil(t, err)
+	assert.Equal(t, MustEncode(deck), buf.String())
+	assert.Equal(t, buf.Len(), n)
+}
+
+func TestEncodeToInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := EncodeTo(&buf, Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{10, 1}, {20, 0}}})
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 0, buf.Len())
 }
@@ -0,0 +1,361 @@
+package deckstrings
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DecodeInfo describes the structure of a decoded deckstring.
+type DecodeInfo struct {
+	// Reserved is the value of the reserved field preceding the version,
+	// which is always 0 unless decoding with AllowReserved.
+	Reserved uint64
+
+	// Version is the deckstring encoding version.
+	Version uint64
+
+	// PayloadLength is the length in bytes of the base64-decoded payload,
+	// including any trailing bytes.
+	PayloadLength int
+
+	// GroupLengths is the number of cards listed in each card group: 1x cards,
+	// 2x cards, and cards with an explicit count, in that order.
+	GroupLengths [3]int
+
+	// TrailingBytes is the number of payload bytes following the last card group.
+	TrailingBytes int
+
+	// Trailing holds the payload bytes following the last card group, unparsed,
+	// so callers can handle data added by newer encodings. It is nil if there
+	// are no trailing bytes.
+	Trailing []byte
+
+	// HasSideboards reports whether the trailing bytes begin with the sideboard
+	// flag written by newer Hearthstone clients for decks with sideboards. Newer
+	// clients write a single zero byte for decks without sideboards. Sideboards
+	// are not decoded by this package.
+	HasSideboards bool
+
+	// Entries lists every card entry in wire order along with the group it was
+	// encoded in, before sorting and merging. Together with the format and
+	// heroes, it describes the card groups exactly, for tooling that needs to
+	// reproduce a deckstring byte for byte. Sideboard entries are not
+	// included since sideboards are not decoded.
+	Entries []CardEntry
+
+	// Warnings lists suspicious properties of the deckstring, in wire order.
+	// Warnings do not prevent decoding; the returned deck is normalized as usual.
+	Warnings []Warning
+}
+
+// CardEntry is a single card entry as encoded in a deckstring.
+type CardEntry struct {
+	DBFID uint64
+	Count uint64
+
+	// Group is the card group the entry was encoded in: 1 for 1x cards, 2 for
+	// 2x cards, or 3 for cards with an explicit count.
+	Group int
+}
+
+// DecodeWithInfo decodes a deckstring like Decode and additionally returns
+// structural metadata about the encoding, useful for analytics and
+// compatibility reporting.
+func DecodeWithInfo(deckstring string, opts ...DecodeOption) (deck Deck, info DecodeInfo, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring decode")
+		}
+	}()
+
+	return decode(newPayloadReader(deckstring), newDecodeOptions(opts))
+}
+
+// decode decodes a deckstring payload with the codec registered for its
+// version, logging the result if the options have a logger and reporting it
+// to the registered Metrics.
+func decode(reader io.ByteReader, options *decodeOptions) (Deck, DecodeInfo, error) {
+	metrics := loadMetrics()
+	var start time.Time
+	if metrics != nil {
+		start = time.Now()
+	}
+
+	deck, info, err := decodePayload(reader, options)
+	if metrics != nil {
+		metrics.ObserveDecode(time.Since(start), err)
+	}
+	if logger := options.logger; logger != nil {
+		if err != nil {
+			logger.Warn("deckstring decode failed", "error", err.Error())
+		}
+		for _, warning := range info.Warnings {
+			logger.Debug("deckstring decode warning", "kind", warning.Kind.String(), "offset", warning.Offset, "message", warning.Message)
+		}
+	}
+	return deck, info, err
+}
+
+// decodePayload decodes a deckstring payload with the codec registered for
+// its version.
+func decodePayload(reader io.ByteReader, options *decodeOptions) (Deck, DecodeInfo, error) {
+	reader, err := decompress(reader)
+	if err != nil {
+		return Deck{}, DecodeInfo{}, err
+	}
+
+	reader, version := peekVersion(reader)
+	codec, ok := lookupCodec(version)
+	if !ok {
+		// Let the version 1 parser report the unsupported version.
+		codec = versionOneCodec{}
+	}
+
+	return codec.decode(reader, options)
+}
+
+// decodeVersionOne decodes the payload of a version 1 deckstring.
+func decodeVersionOne(reader io.ByteReader, options *decodeOptions) (Deck, DecodeInfo, error) {
+	var info DecodeInfo
+
+	// The limit applies to the decompressed payload, so small compressed
+	// deckstrings can't expand into large decks.
+	limited := &limitedReader{reader: reader, limit: options.maxPayloadLength}
+	reader = limited
+
+	// fits reports whether n more fields of at least one byte each could follow
+	// a field ending at offset end without exceeding the payload limit.
+	fits := func(n uint64, end int) bool {
+		return limited.limit <= 0 || n <= uint64(limited.limit-end)
+	}
+
+	var format Format
+	var heroes []uint64
+	cards := make([][2]uint64, 0, 30)
+
+	warn := func(kind WarningKind, field Field, format string, args ...interface{}) {
+		info.Warnings = append(info.Warnings, Warning{
+			Kind:    kind,
+			Offset:  field.Offset,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	// addCount adds a card's count to the running total, enforcing
+	// MaxTotalCount. The total never exceeds the limit, so max-total can't
+	// underflow and huge counts can't overflow the total.
+	var total uint64
+	addCount := func(count uint64) error {
+		if max := options.maxTotalCount; max > 0 && count > max-total {
+			return fmt.Errorf("total card count exceeds limit of %d", max)
+		}
+		total += count
+		return nil
+	}
+
+	var dbfID uint64
+	seen := make(map[uint64]bool)
+	relaxed := parseOptions{newerVersions: options.newerVersions, anyReserved: options.anyReserved}
+
+	end := 0
+	err := parseWith(reader, relaxed, func(field Field) error {
+		end = field.Offset + field.Length
+
+		if field.Length > uvarintLen(field.Value) {
+			if options.strictVarints {
+				return fmt.Errorf("overlong varint for %s at offset %d", field.Kind, field.Offset)
+			}
+			warn(WarningOverlongVarint, field, "%s %d encoded in %d bytes instead of %d", field.Kind, field.Value, field.Length, uvarintLen(field.Value))
+		}
+
+		if max := options.maxDBFID; max > 0 && (field.Kind == FieldHero || field.Kind == FieldCard) && field.Value > max {
+			return fmt.Errorf("%s DBF ID %d exceeds limit of %d", field.Kind, field.Value, max)
+		}
+
+		switch field.Kind {
+		case FieldReserved:
+			info.Reserved = field.Value
+		case FieldVersion:
+			info.Version = field.Value
+			if field.Value > Version {
+				warn(WarningNewerVersion, field, "version %d is newer than supported version %d; decoded as version %d", field.Value, Version, Version)
+			}
+		case FieldFormat:
+			format = Format(field.Value)
+			if !options.allowsFormat(format) {
+				return fmt.Errorf("unsupported format: %s", format)
+			}
+			if !format.Known() {
+				warn(WarningUnknownFormat, field, "format %d is not a known format", field.Value)
+			}
+		case FieldHeroCount:
+			if !fits(field.Value, end) {
+				return fmt.Errorf("hero count %d exceeds payload limit of %d bytes", field.Value, limited.limit)
+			}
+			if max := options.maxHeroes; max > 0 && field.Value > uint64(max) {
+				return fmt.Errorf("hero count %d exceeds limit of %d", field.Value, max)
+			}
+			// Don't trust the count for preallocation when the limit is off.
+			heroes = make([]uint64, 0, min(field.Value, 4))
+			if field.Value == 0 {
+				warn(WarningNoHeroes, field, "deck has no heroes")
+			}
+		case FieldHero:
+			if n := len(heroes); n > 0 && field.Value < heroes[n-1] {
+				warn(WarningUnsortedHeroes, field, "hero %d listed after hero %d", field.Value, heroes[n-1])
+			}
+			heroes = append(heroes, field.Value)
+		case FieldGroupLength:
+			if !fits(field.Value, end) {
+				return fmt.Errorf("group %d length %d exceeds payload limit of %d bytes", field.Group, field.Value, limited.limit)
+			}
+			info.GroupLengths[field.Group-1] = int(field.Value)
+			dbfID = 0
+		case FieldCard:
+			if field.Value < dbfID {
+				warn(WarningUnsortedCards, field, "card %d listed after card %d in group %d", field.Value, dbfID, field.Group)
+			}
+			dbfID = field.Value
+			if seen[dbfID] {
+				warn(WarningDuplicateCard, field, "card %d listed more than once", dbfID)
+			}
+			seen[dbfID] = true
+			if max := options.maxDistinctCards; max > 0 && len(seen) > max {
+				return fmt.Errorf("distinct card count exceeds limit of %d", max)
+			}
+			if field.Group < 3 {
+				if err := addCount(uint64(field.Group)); err != nil {
+					return err
+				}
+				cards = append(cards, [2]uint64{dbfID, uint64(field.Group)})
+				info.Entries = append(info.Entries, CardEntry{DBFID: dbfID, Count: uint64(field.Group), Group: field.Group})
+			}
+		case FieldCount:
+			if field.Value > 2 {
+				warn(WarningHighCount, field, "card %d has count %d", dbfID, field.Value)
+			}
+			if err := addCount(field.Value); err != nil {
+				return err
+			}
+			cards = append(cards, [2]uint64{dbfID, field.Value})
+			info.Entries = append(info.Entries, CardEntry{DBFID: dbfID, Count: field.Value, Group: field.Group})
+		}
+		return nil
+	})
+
+	if err != nil {
+		return Deck{}, DecodeInfo{}, err
+	}
+
+	trailing := readRemaining(reader)
+	if limited.exceeded {
+		return Deck{}, DecodeInfo{}, limited.err()
+	}
+	info.PayloadLength = end + len(trailing)
+	info.TrailingBytes = len(trailing)
+	info.Trailing = trailing
+
+	// Anything beyond the zero byte marking a deck without sideboards is data
+	// from a newer revision of the format. It is skipped and reported rather
+	// than failing the decode, unless the caller asked for strictness.
+	if !(len(trailing) == 0 || (len(trailing) == 1 && trailing[0] == 0)) {
+		if options.rejectTrailing {
+			return Deck{}, DecodeInfo{}, fmt.Errorf("unexpected trailing data: %d bytes", len(trailing))
+		}
+		info.Warnings = append(info.Warnings, Warning{
+			Kind:    WarningTrailingData,
+			Offset:  end,
+			Message: fmt.Sprintf("%d bytes after the card groups were not decoded", len(trailing)),
+		})
+	}
+	info.HasSideboards = len(trailing) > 0 && trailing[0] == 1
+
+	// Sort heroes.
+	sort.Slice(heroes, func(i, j int) bool { return heroes[i] < heroes[j] })
+
+	// Sort cards by DBF ID, keeping duplicate entries in wire order.
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i][0] < cards[j][0] })
+
+	if !options.keepDuplicates {
+		cards = mergeSorted(cards)
+	}
+
+	// Deck.Version is left 0 even for a newer version decoded on a best-effort
+	// basis: there is no codec to re-encode that version, but the deck was
+	// read as version 1 and so encodes as one. DecodeInfo reports the version
+	// read.
+	deck := Deck{
+		Format: format,
+		Heroes: heroes,
+		Cards:  cards,
+	}
+
+	return deck, info, nil
+}
+
+// readRemaining reads bytes until the reader is exhausted or fails. Data past
+// the card groups is not validated, so read errors there are ignored.
+func readRemaining(reader io.ByteReader) []byte {
+	var remaining []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return remaining
+		}
+		remaining = append(remaining, b)
+	}
+}
+
+// mergeSorted sums the counts of adjacent entries with the same DBF ID in
+// cards, which must be sorted by DBF ID. The slice is modified in place.
+func mergeSorted(cards [][2]uint64) [][2]uint64 {
+	merged := cards[:0]
+	for _, card := range cards {
+		if n := len(merged); n > 0 && merged[n-1][0] == card[0] {
+			merged[n-1][1] += card[1]
+		} else {
+			merged = append(merged, card)
+		}
+	}
+	return merged
+}
+
+// limitedReader fails reads past limit bytes. A limit of 0 or less means no
+// limit. exceeded records a failed read so callers that ignore read errors,
+// like readRemaining, can still detect oversized input.
+type limitedReader struct {
+	reader   io.ByteReader
+	limit    int
+	read     int
+	exceeded bool
+}
+
+func (r *limitedReader) ReadByte() (byte, error) {
+	if r.limit > 0 && r.read >= r.limit {
+		if _, err := r.reader.ReadByte(); err != nil {
+			return 0, err
+		}
+		r.exceeded = true
+		return 0, r.err()
+	}
+
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.read++
+	}
+	return b, err
+}
+
+func (r *limitedReader) err() error {
+	return fmt.Errorf("payload exceeds limit of %d bytes", r.limit)
+}
+
+// uvarintLen returns the number of bytes in the minimal uvarint encoding of v.
+func uvarintLen(v uint64) int {
+	return (bits.Len64(v|1) + 6) / 7
+}
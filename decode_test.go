@@ -0,0 +1,294 @@
+package deckstrings_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeWithInfo(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	deck, info, err := DecodeWithInfo(deckstring)
+	assert.Nil(t, err)
+	assert.Len(t, info.Entries, 18)
+	info.Entries = nil
+	assert.Equal(t, DecodeInfo{
+		Version:       1,
+		PayloadLength: 44,
+		GroupLengths:  [3]int{6, 12, 0},
+	}, info)
+
+	decoded, err := Decode(deckstring)
+	assert.Nil(t, err)
+	assert.Equal(t, decoded, deck, "decks should be equal")
+}
+
+func TestDecodeWithInfoHighCount(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAEAAAAACAEDAgMDAwQEBQQGCgdkCOgH")
+	assert.Nil(t, err)
+	assert.Equal(t, [3]int{0, 0, 8}, info.GroupLengths)
+}
+
+func TestDecodeWithInfoNoSideboards(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAAA")
+	assert.Nil(t, err)
+	assert.Equal(t, 45, info.PayloadLength)
+	assert.Equal(t, 1, info.TrailingBytes)
+	assert.False(t, info.HasSideboards)
+}
+
+func TestDecodeWithInfoSideboards(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAABAY0BHwAA")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, info.TrailingBytes)
+	assert.True(t, info.HasSideboards)
+}
+
+func TestDecodeWithInfoInvalid(t *testing.T) {
+	_, _, err := DecodeWithInfo("AAEB0")
+	assert.NotNil(t, err)
+}
+
+func TestDecodeWithInfoWarnings(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAEAAgIBAAAA")
+	assert.Nil(t, err)
+	assert.Equal(t, []Warning{
+		{Kind: WarningUnknownFormat, Offset: 2, Message: "format 0 is not a known format"},
+		{Kind: WarningUnsortedHeroes, Offset: 5, Message: "hero 1 listed after hero 2"},
+	}, info.Warnings)
+
+	_, info, err = DecodeWithInfo("AAEAAAMDAgEAAA==")
+	assert.Nil(t, err)
+	assert.Equal(t, []WarningKind{
+		WarningUnknownFormat,
+		WarningNoHeroes,
+		WarningUnsortedCards,
+		WarningUnsortedCards,
+	}, warningKinds(info.Warnings))
+
+	_, info, err = DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Empty(t, info.Warnings)
+}
+
+func TestDecodeWithInfoHighCountWarnings(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAEAAAAACAEDAgMDAwQEBQQGCgdkCOgH")
+	assert.Nil(t, err)
+
+	kinds := warningKinds(info.Warnings)
+	assert.Len(t, kinds, 10)
+	assert.Equal(t, WarningHighCount, kinds[9])
+	assert.Equal(t, "high count at offset 22: card 8 has count 1000", info.Warnings[9].String())
+}
+
+func warningKinds(warnings []Warning) []WarningKind {
+	kinds := make([]WarningKind, len(warnings))
+	for i, warning := range warnings {
+		kinds[i] = warning.Kind
+	}
+	return kinds
+}
+
+func TestDecodeMergesDuplicates(t *testing.T) {
+	// DBF ID 5 is listed in each of the 1x, 2x, and n-count groups.
+	deck, info, err := DecodeWithInfo("AAEBAQcBBQEFAQUD")
+	assert.Nil(t, err)
+	assert.Equal(t, [][2]uint64{{5, 6}}, deck.Cards)
+	assert.Equal(t, []Warning{
+		{Kind: WarningDuplicateCard, Offset: 8, Message: "card 5 listed more than once"},
+		{Kind: WarningDuplicateCard, Offset: 10, Message: "card 5 listed more than once"},
+		{Kind: WarningHighCount, Offset: 11, Message: "card 5 has count 3"},
+	}, info.Warnings)
+}
+
+func TestDecodeKeepDuplicates(t *testing.T) {
+	deck, err := Decode("AAEBAQcBBQEFAQUD", KeepDuplicates())
+	assert.Nil(t, err)
+	assert.Equal(t, [][2]uint64{{5, 1}, {5, 2}, {5, 3}}, deck.Cards)
+}
+
+func TestDecodeHugeHeroCount(t *testing.T) {
+	// Declares 2^40 heroes.
+	_, err := Decode("AAEBgICAgIAg")
+	assert.EqualError(t, err, "deckstring decode: hero count 1099511627776 exceeds payload limit of 4096 bytes")
+
+	_, err = Decode("AAEBgICAgIAg", MaxPayloadLength(0))
+	assert.NotNil(t, err)
+}
+
+func TestDecodeHugeGroupLength(t *testing.T) {
+	// Declares 2^40 cards in the 1x group.
+	_, err := Decode("AAEBAQeAgICAgCA=")
+	assert.EqualError(t, err, "deckstring decode: group 1 length 1099511627776 exceeds payload limit of 4096 bytes")
+}
+
+func TestDecodeMaxPayloadLength(t *testing.T) {
+	payload := append([]byte{0, 1, 1, 1, 7, 0, 0, 0}, make([]byte, 5000)...)
+	deckstring := base64.StdEncoding.EncodeToString(payload)
+
+	_, err := Decode(deckstring)
+	assert.EqualError(t, err, "deckstring decode: payload exceeds limit of 4096 bytes")
+
+	_, info, err := DecodeWithInfo(deckstring, MaxPayloadLength(0))
+	assert.Nil(t, err)
+	assert.Equal(t, 5000, info.TrailingBytes)
+
+	_, err = Decode("AAEBAQcAAAA=", MaxPayloadLength(8))
+	assert.Nil(t, err)
+
+	_, err = Decode("AAEBAQcAAAA=", MaxPayloadLength(7))
+	assert.NotNil(t, err)
+
+	_, err = Inspect(deckstring)
+	assert.NotNil(t, err)
+}
+
+func TestDecodeMaxHeroes(t *testing.T) {
+	// Two heroes.
+	deckstring := "AAEAAgIBAAAA"
+	_, err := Decode(deckstring, MaxHeroes(2))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring, MaxHeroes(1))
+	assert.EqualError(t, err, "deckstring decode: hero count 2 exceeds limit of 1")
+}
+
+func TestDecodeMaxDistinctCards(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	_, err := Decode(deckstring, MaxDistinctCards(18))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring, MaxDistinctCards(17))
+	assert.EqualError(t, err, "deckstring decode: distinct card count exceeds limit of 17")
+
+	// Duplicate entries for a card count once.
+	_, err = Decode("AAEBAQcBBQEFAQUD", MaxDistinctCards(1))
+	assert.Nil(t, err)
+}
+
+func TestDecodeMaxTotalCount(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	_, err := Decode(deckstring, MaxTotalCount(30))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring, MaxTotalCount(29))
+	assert.EqualError(t, err, "deckstring decode: total card count exceeds limit of 29")
+
+	// A single huge count must not overflow the running total.
+	huge, err := Encode(Deck{Cards: [][2]uint64{{1, 1}, {2, ^uint64(0)}}})
+	assert.Nil(t, err)
+	_, err = Decode(huge, MaxTotalCount(30))
+	assert.NotNil(t, err)
+}
+
+func TestDecodeOverlongVarint(t *testing.T) {
+	// Hero 7 encoded as 0x87 0x00.
+	deckstring := "AAEBAYcAAAAA"
+
+	deck, info, err := DecodeWithInfo(deckstring)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{7}, deck.Heroes)
+	assert.Equal(t, []Warning{
+		{Kind: WarningOverlongVarint, Offset: 4, Message: "hero 7 encoded in 2 bytes instead of 1"},
+	}, info.Warnings)
+
+	_, err = Decode(deckstring, StrictVarints())
+	assert.EqualError(t, err, "deckstring decode: overlong varint for hero at offset 4")
+
+	_, err = Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=", StrictVarints())
+	assert.Nil(t, err)
+}
+
+func TestDecodeMaxDBFID(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	_, err := Decode(deckstring, MaxDBFID(1700))
+	assert.Nil(t, err)
+
+	_, err = Decode(deckstring, MaxDBFID(1000))
+	assert.NotNil(t, err)
+
+	_, err = Decode(deckstring, MaxDBFID(30))
+	assert.EqualError(t, err, "deckstring decode: hero DBF ID 31 exceeds limit of 30")
+}
+
+func TestDecodeTrailing(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAABAY0BHwAA")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 1, 141, 1, 31, 0, 0}, info.Trailing)
+	assert.Equal(t, []Warning{
+		{Kind: WarningTrailingData, Offset: 44, Message: "7 bytes after the card groups were not decoded"},
+	}, info.Warnings)
+
+	_, info, err = DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Nil(t, info.Trailing)
+}
+
+func TestDecodeRejectTrailingData(t *testing.T) {
+	_, err := Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=", RejectTrailingData())
+	assert.Nil(t, err)
+
+	// Sideboard flag for a deck without sideboards.
+	_, err = Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAAA", RejectTrailingData())
+	assert.Nil(t, err)
+
+	_, err = Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAABAY0BHwAA", RejectTrailingData())
+	assert.EqualError(t, err, "deckstring decode: unexpected trailing data: 7 bytes")
+
+	_, err = Decode("AAEBAQcAAAAH", RejectTrailingData())
+	assert.NotNil(t, err)
+}
+
+func TestDecodeExtraGroups(t *testing.T) {
+	// A hypothetical fourth group listing card 9 follows the sideboard flag.
+	deck, info, err := DecodeWithInfo("AAEBAQcAAAAAAQk=")
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{Format: FormatWild, Heroes: []uint64{7}, Cards: [][2]uint64{}}, deck)
+	assert.Equal(t, []byte{0, 1, 9}, info.Trailing)
+	assert.Equal(t, WarningTrailingData, info.Warnings[0].Kind)
+	assert.Equal(t, 8, info.Warnings[0].Offset)
+}
+
+func TestDecodeEntries(t *testing.T) {
+	// Cards 3, 2, 1 in the 1x group, then card 1 with an explicit count of 1.
+	_, info, err := DecodeWithInfo("AAEAAAMDAgEAAQEB")
+	assert.Nil(t, err)
+	assert.Equal(t, []CardEntry{
+		{DBFID: 3, Count: 1, Group: 1},
+		{DBFID: 2, Count: 1, Group: 1},
+		{DBFID: 1, Count: 1, Group: 1},
+		{DBFID: 1, Count: 1, Group: 3},
+	}, info.Entries)
+}
+
+func TestDecodeNewerVersions(t *testing.T) {
+	_, err := Decode("AAIBAQcAAAA=")
+	assert.EqualError(t, err, "deckstring decode: unsupported version: 2")
+
+	deck, info, err := DecodeWithInfo("AAIBAQcAAAA=", DecodeNewerVersions())
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{Format: FormatWild, Heroes: []uint64{7}, Cards: [][2]uint64{}}, deck)
+	assert.Equal(t, uint64(2), info.Version)
+	assert.Equal(t, []Warning{{
+		Kind:    WarningNewerVersion,
+		Offset:  1,
+		Message: "version 2 is newer than supported version 1; decoded as version 1",
+	}}, info.Warnings)
+
+	// Data past the known structure is reported as trailing.
+	_, info, err = DecodeWithInfo("AAIBAQcAAAAAAQk=", DecodeNewerVersions())
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 1, 9}, info.Trailing)
+	assert.Equal(t, WarningTrailingData, info.Warnings[1].Kind)
+
+	// A payload that doesn't follow the known structure still fails.
+	_, err = Decode("AAIBAQcA", DecodeNewerVersions())
+	assert.EqualError(t, err, "deckstring decode: EOF")
+
+	// Older versions are not affected.
+	_, err = Decode("AAABAQcAAAA=", DecodeNewerVersions())
+	assert.EqualError(t, err, "deckstring decode: unsupported version: 0")
+}
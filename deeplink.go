@@ -0,0 +1,56 @@
+package deckstrings
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DeepLinkScheme is the URL scheme registered by the Hearthstone client.
+//
+// Blizzard does not publish a specification for the scheme. Links take the
+// form hearthstone://deckbuilder?deckcode=..., mirroring the query of the web
+// deck builder at https://hearthstone.blizzard.com/deckbuilder.
+const DeepLinkScheme = "hearthstone"
+
+// deepLinkHost is the host of deep links that open the deck builder.
+const deepLinkHost = "deckbuilder"
+
+// DeepLink returns a link that opens the Hearthstone client's deck builder
+// with the deck, for "open in Hearthstone" buttons in companion apps, e.g.
+// "hearthstone://deckbuilder?deckcode=AAECAR8G...%2BDAA%3D". Options are
+// passed to Encode.
+func DeepLink(deck Deck, opts ...EncodeOption) (string, error) {
+	deckstring, err := Encode(deck, opts...)
+	if err != nil {
+		return "", errors.Wrap(err, "deep link")
+	}
+	return DeepLinkScheme + "://" + deepLinkHost + "?deckcode=" + url.QueryEscape(deckstring), nil
+}
+
+// DeckstringFromDeepLink extracts the deck code from a link created by
+// DeepLink. Unlike DeckstringFromURL, links with other schemes or that open
+// other screens than the deck builder are rejected.
+// The code is decoded to check that it is valid; options are passed to Decode.
+func DeckstringFromDeepLink(link string, opts ...DecodeOption) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", errors.Wrap(err, "deep link")
+	}
+
+	if u.Scheme != DeepLinkScheme {
+		return "", fmt.Errorf("deep link: unexpected scheme: %q", u.Scheme)
+	}
+
+	if !strings.EqualFold(u.Host, deepLinkHost) {
+		return "", fmt.Errorf("deep link: unexpected host: %q", u.Host)
+	}
+
+	deckstring, _, err := parseDeckURL(link, opts)
+	if err != nil {
+		return "", errors.Wrap(err, "deep link")
+	}
+	return deckstring, nil
+}
@@ -0,0 +1,39 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepLink(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	link, err := DeepLink(MustDecode(deckstring))
+	assert.Nil(t, err)
+	assert.Equal(t, "hearthstone://deckbuilder?deckcode=AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D", link)
+
+	parsed, err := DeckstringFromDeepLink(link)
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring, parsed)
+
+	// Deep links are URLs too.
+	parsed, err = DeckstringFromURL(link)
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring, parsed)
+}
+
+func TestDeepLinkInvalid(t *testing.T) {
+	_, err := DeepLink(Deck{Cards: [][2]uint64{{1, 0}}})
+	assert.EqualError(t, err, "deep link: deckstring encode: invalid card count for DBF ID 1")
+
+	_, err = DeckstringFromDeepLink("https://hearthstone.blizzard.com/deckbuilder?deckcode=AAEBAQcAAAAA")
+	assert.EqualError(t, err, `deep link: unexpected scheme: "https"`)
+
+	_, err = DeckstringFromDeepLink("hearthstone://collection?deckcode=AAEBAQcAAAAA")
+	assert.EqualError(t, err, `deep link: unexpected host: "collection"`)
+
+	_, err = DeckstringFromDeepLink("hearthstone://deckbuilder")
+	assert.EqualError(t, err, `deep link: no deck code in URL: "hearthstone://deckbuilder"`)
+}
@@ -0,0 +1,260 @@
+package deckstrings
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The delta encoding version supported by this package.
+const DeltaVersion = 1
+
+// Flags marking the optional fields of a delta.
+const (
+	deltaFormatChanged  = 1 << 0
+	deltaVersionChanged = 1 << 1
+)
+
+// EncodeDelta encodes the difference between a base deck and a variant of it
+// into a compact base64 string. The variant can be recovered with DecodeDelta
+// given the same base deck. Deltas are typically far shorter than full
+// deckstrings, which makes them suitable for storing long edit histories.
+//
+// A delta is a base64-encoded sequence of varints:
+//
+//	version             DeltaVersion
+//	checksum            CRC-32 (IEEE) of the base deck's canonical deckstring
+//	flags               bit 0 set if the format changed, bit 1 if the version did
+//	[format]            the variant's format, if changed
+//	[version]           the variant's deckstring version, if changed
+//	removed hero count  followed by each hero removed
+//	added hero count    followed by each hero added
+//	card change count   followed by (DBF ID gap, signed count delta) pairs
+//
+// Card changes are ordered by DBF ID ascending. Each DBF ID is written as the
+// gap from the previous DBF ID, and each count delta is zigzag encoded.
+//
+// Returns an error if either deck cannot be encoded. Duplicate DBF IDs in
+// either deck are summed; the recovered variant is canonical.
+func EncodeDelta(base, variant Deck) (delta string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring delta encode")
+		}
+	}()
+
+	checksum, err := deckChecksum(base)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := Encode(variant); err != nil {
+		return "", err
+	}
+
+	diff := Diff(base, variant)
+
+	var buf bytes.Buffer
+	writer := base64.NewEncoder(base64.StdEncoding, &buf)
+	varint := &varintWriter{writer}
+
+	var flags uint64
+	var changed []uint64
+	if diff.FromFormat != diff.ToFormat {
+		flags |= deltaFormatChanged
+		changed = append(changed, uint64(diff.ToFormat))
+	}
+	if base.version() != variant.version() {
+		flags |= deltaVersionChanged
+		changed = append(changed, variant.version())
+	}
+
+	values := []uint64{DeltaVersion, uint64(checksum), flags}
+	values = append(values, changed...)
+
+	values = append(values, uint64(len(diff.HeroesRemoved)))
+	values = append(values, diff.HeroesRemoved...)
+	values = append(values, uint64(len(diff.HeroesAdded)))
+	values = append(values, diff.HeroesAdded...)
+
+	if err = varint.WriteMany(values); err != nil {
+		return "", err
+	}
+
+	changes := make(map[uint64]int64)
+	for _, card := range diff.Removed {
+		changes[card[0]] = -int64(card[1])
+	}
+	for _, card := range diff.Added {
+		changes[card[0]] = int64(card[1])
+	}
+	for _, change := range diff.Changed {
+		changes[change.DBFID] = change.Delta()
+	}
+
+	dbfIDs := make([]uint64, 0, len(changes))
+	for dbfID := range changes {
+		dbfIDs = append(dbfIDs, dbfID)
+	}
+	sort.Slice(dbfIDs, func(i, j int) bool { return dbfIDs[i] < dbfIDs[j] })
+
+	if err = varint.Write(uint64(len(dbfIDs))); err != nil {
+		return "", err
+	}
+
+	previous := uint64(0)
+	for _, dbfID := range dbfIDs {
+		if err = varint.Write(dbfID - previous); err != nil {
+			return "", err
+		}
+		if err = varint.WriteSigned(changes[dbfID]); err != nil {
+			return "", err
+		}
+		previous = dbfID
+	}
+
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// DecodeDelta applies a delta produced by EncodeDelta to base, returning the
+// variant deck. The result is canonical: heroes and cards are ordered by DBF
+// ID ascending and each card's DBF ID appears once.
+//
+// Returns an error if the delta is malformed, if it was encoded against a
+// different base deck, or if it cannot be applied to base.
+func DecodeDelta(base Deck, delta string) (deck Deck, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring delta decode")
+		}
+	}()
+
+	checksum, err := deckChecksum(base)
+	if err != nil {
+		return Deck{}, err
+	}
+
+	varint := &varintReader{reader: newPayloadReader(delta)}
+
+	header := [3]uint64{}
+	for i := range header {
+		if header[i], err = varint.Read(); err != nil {
+			return Deck{}, err
+		}
+	}
+
+	if version := header[0]; version != DeltaVersion {
+		return Deck{}, fmt.Errorf("unsupported delta version: %d", version)
+	}
+
+	if header[1] != uint64(checksum) {
+		return Deck{}, fmt.Errorf("delta does not match base deck")
+	}
+
+	flags := header[2]
+	if flags&^(deltaFormatChanged|deltaVersionChanged) != 0 {
+		return Deck{}, fmt.Errorf("unsupported delta flags: %d", flags)
+	}
+
+	format := base.Format
+	if flags&deltaFormatChanged != 0 {
+		value, err := varint.Read()
+		if err != nil {
+			return Deck{}, err
+		}
+		format = Format(value)
+	}
+
+	version := base.Version
+	if flags&deltaVersionChanged != 0 {
+		if version, err = varint.Read(); err != nil {
+			return Deck{}, err
+		}
+		if version == Version {
+			version = 0
+		}
+	}
+
+	heroes := heroSet(base.Heroes)
+	for _, add := range []bool{false, true} {
+		length, err := varint.Read()
+		if err != nil {
+			return Deck{}, err
+		}
+
+		for i := uint64(0); i < length; i++ {
+			hero, err := varint.Read()
+			if err != nil {
+				return Deck{}, err
+			}
+
+			if add {
+				heroes[hero] = 1
+			} else if _, ok := heroes[hero]; ok {
+				delete(heroes, hero)
+			} else {
+				return Deck{}, fmt.Errorf("removed hero %d not in base deck", hero)
+			}
+		}
+	}
+
+	counts := cardCountsByID(base.Cards)
+
+	length, err := varint.Read()
+	if err != nil {
+		return Deck{}, err
+	}
+
+	dbfID := uint64(0)
+	for i := uint64(0); i < length; i++ {
+		gap, err := varint.Read()
+		if err != nil {
+			return Deck{}, err
+		}
+
+		change, err := varint.ReadSigned()
+		if err != nil {
+			return Deck{}, err
+		}
+
+		dbfID += gap
+		count := int64(counts[dbfID]) + change
+		if count < 0 {
+			return Deck{}, fmt.Errorf("invalid card count change for DBF ID %d", dbfID)
+		}
+
+		counts[dbfID] = uint64(count)
+	}
+
+	cards := make([][2]uint64, 0, len(counts))
+	for _, dbfID := range sortedKeys(counts) {
+		if counts[dbfID] > 0 {
+			cards = append(cards, [2]uint64{dbfID, counts[dbfID]})
+		}
+	}
+
+	return Deck{
+		Format:  format,
+		Heroes:  sortedKeys(heroes),
+		Cards:   cards,
+		Version: version,
+	}, nil
+}
+
+// deckChecksum returns the CRC-32 of the deck's canonical deckstring.
+func deckChecksum(deck Deck) (uint32, error) {
+	deckstring, err := Encode(Canonicalize(deck))
+	if err != nil {
+		return 0, err
+	}
+
+	return crc32.ChecksumIEEE([]byte(deckstring)), nil
+}
@@ -0,0 +1,89 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelta(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	base, err := Decode(deckstring)
+	assert.Nil(t, err)
+
+	variant, err := Decode(deckstring)
+	assert.Nil(t, err)
+	variant.Cards = variant.Cards[2:]
+	variant.Cards[0][1] = 1
+	variant.Cards = append(variant.Cards, [2]uint64{50000, 2})
+
+	delta, err := EncodeDelta(base, variant)
+	assert.Nil(t, err)
+	assert.True(t, len(delta) < len(deckstring)/2, "delta should be compact: %s", delta)
+
+	decoded, err := DecodeDelta(base, delta)
+	assert.Nil(t, err)
+	assert.Equal(t, variant, decoded, "decks should be equal")
+}
+
+func TestDeltaFormatAndHeroes(t *testing.T) {
+	base := Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}}
+	variant := Deck{Format: FormatWild, Heroes: []uint64{31}, Cards: [][2]uint64{{1, 2}}}
+
+	delta, err := EncodeDelta(base, variant)
+	assert.Nil(t, err)
+
+	decoded, err := DecodeDelta(base, delta)
+	assert.Nil(t, err)
+	assert.Equal(t, variant, decoded, "decks should be equal")
+}
+
+func TestDeltaVersion(t *testing.T) {
+	base := Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}}
+	versioned := Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}, Version: 99}
+
+	for _, decks := range [][2]Deck{{base, versioned}, {versioned, base}, {versioned, versioned}} {
+		delta, err := EncodeDelta(decks[0], decks[1])
+		assert.Nil(t, err)
+
+		decoded, err := DecodeDelta(decks[0], delta)
+		assert.Nil(t, err)
+		assert.Equal(t, decks[1], decoded, "decks should be equal")
+	}
+}
+
+func TestDeltaIdentical(t *testing.T) {
+	base := Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}}
+
+	delta, err := EncodeDelta(base, base)
+	assert.Nil(t, err)
+
+	decoded, err := DecodeDelta(base, delta)
+	assert.Nil(t, err)
+	assert.Equal(t, base, decoded, "decks should be equal")
+}
+
+func TestDeltaWrongBase(t *testing.T) {
+	base := Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}}
+	variant := Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{1, 1}}}
+
+	delta, err := EncodeDelta(base, variant)
+	assert.Nil(t, err)
+
+	_, err = DecodeDelta(variant, delta)
+	assert.NotNil(t, err)
+}
+
+func TestDeltaInvalid(t *testing.T) {
+	base := Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{1, 2}}}
+
+	_, err := DecodeDelta(base, "")
+	assert.NotNil(t, err)
+
+	_, err = DecodeDelta(base, "AgA=")
+	assert.NotNil(t, err)
+
+	_, err = EncodeDelta(base, Deck{Cards: [][2]uint64{{1, 0}}})
+	assert.NotNil(t, err)
+}
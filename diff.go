@@ -0,0 +1,100 @@
+package deckstrings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CardDelta is a change in the count of a card shared by two decks.
+type CardDelta struct {
+	DBFID uint64
+	From  uint64
+	To    uint64
+}
+
+// Delta returns the signed change in count, e.g. -1 when going from 2 to 1.
+func (d CardDelta) Delta() int64 {
+	return int64(d.To) - int64(d.From)
+}
+
+// DeckDiff describes the differences between two decks, a and b.
+//
+// Removed lists cards only in a with their count in a. Added lists cards only
+// in b with their count in b. Changed lists cards in both decks with different
+// counts. HeroesRemoved and HeroesAdded list heroes only in a or only in b,
+// respectively. All lists are ordered by DBF ID ascending.
+type DeckDiff struct {
+	FromFormat    Format
+	ToFormat      Format
+	HeroesRemoved []uint64
+	HeroesAdded   []uint64
+	Removed       [][2]uint64
+	Added         [][2]uint64
+	Changed       []CardDelta
+}
+
+// Diff compares deck a to deck b. Decks are compared canonically: card order
+// does not matter and duplicate entries for a DBF ID are summed.
+func Diff(a, b Deck) DeckDiff {
+	diff := DeckDiff{FromFormat: a.Format, ToFormat: b.Format}
+
+	heroesA, heroesB := heroSet(a.Heroes), heroSet(b.Heroes)
+	for _, hero := range sortedKeys(heroesA) {
+		if _, ok := heroesB[hero]; !ok {
+			diff.HeroesRemoved = append(diff.HeroesRemoved, hero)
+		}
+	}
+	for _, hero := range sortedKeys(heroesB) {
+		if _, ok := heroesA[hero]; !ok {
+			diff.HeroesAdded = append(diff.HeroesAdded, hero)
+		}
+	}
+
+	countsA, countsB := cardCountsByID(a.Cards), cardCountsByID(b.Cards)
+	for _, dbfID := range sortedKeys(countsA) {
+		from := countsA[dbfID]
+		if to, ok := countsB[dbfID]; !ok {
+			diff.Removed = append(diff.Removed, [2]uint64{dbfID, from})
+		} else if to != from {
+			diff.Changed = append(diff.Changed, CardDelta{DBFID: dbfID, From: from, To: to})
+		}
+	}
+	for _, dbfID := range sortedKeys(countsB) {
+		if _, ok := countsA[dbfID]; !ok {
+			diff.Added = append(diff.Added, [2]uint64{dbfID, countsB[dbfID]})
+		}
+	}
+
+	return diff
+}
+
+// Empty reports whether the two decks compared are canonically equal.
+func (d DeckDiff) Empty() bool {
+	return d.FromFormat == d.ToFormat &&
+		len(d.HeroesRemoved) == 0 && len(d.HeroesAdded) == 0 &&
+		len(d.Removed) == 0 && len(d.Added) == 0 && len(d.Changed) == 0
+}
+
+// String summarizes the card changes by DBF ID, e.g. "-2 315, +1 662". Format
+// and hero changes are not included.
+func (d DeckDiff) String() string {
+	var changes []string
+	for _, card := range d.Removed {
+		changes = append(changes, fmt.Sprintf("-%d %d", card[1], card[0]))
+	}
+	for _, delta := range d.Changed {
+		changes = append(changes, fmt.Sprintf("%+d %d", delta.Delta(), delta.DBFID))
+	}
+	for _, card := range d.Added {
+		changes = append(changes, fmt.Sprintf("+%d %d", card[1], card[0]))
+	}
+	return strings.Join(changes, ", ")
+}
+
+func heroSet(heroes []uint64) map[uint64]uint64 {
+	set := make(map[uint64]uint64, len(heroes))
+	for _, hero := range heroes {
+		set[hero]++
+	}
+	return set
+}
@@ -0,0 +1,49 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{77, 2}, {315, 2}, {555, 2}, {662, 1}},
+	}
+	b := Deck{
+		Format: FormatWild,
+		Heroes: []uint64{637, 7},
+		Cards:  [][2]uint64{{662, 2}, {555, 2}, {77, 1}, {1004, 1}},
+	}
+
+	diff := Diff(a, b)
+	assert.Equal(t, DeckDiff{
+		FromFormat:  FormatStandard,
+		ToFormat:    FormatWild,
+		HeroesAdded: []uint64{7},
+		Removed:     [][2]uint64{{315, 2}},
+		Added:       [][2]uint64{{1004, 1}},
+		Changed:     []CardDelta{{DBFID: 77, From: 2, To: 1}, {DBFID: 662, From: 1, To: 2}},
+	}, diff)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, "-2 315, -1 77, +1 662, +1 1004", diff.String())
+	assert.Equal(t, int64(-1), diff.Changed[0].Delta())
+}
+
+func TestDiffEqual(t *testing.T) {
+	a := Deck{Heroes: []uint64{31}, Cards: [][2]uint64{{1, 1}, {1, 1}, {2, 2}}}
+	b := Deck{Heroes: []uint64{31}, Cards: [][2]uint64{{2, 2}, {1, 2}}}
+
+	diff := Diff(a, b)
+	assert.True(t, diff.Empty())
+	assert.Equal(t, "", diff.String())
+}
+
+func TestDiffHeroesRemoved(t *testing.T) {
+	diff := Diff(Deck{Heroes: []uint64{31, 7}}, Deck{Heroes: []uint64{31}})
+	assert.Equal(t, []uint64{7}, diff.HeroesRemoved)
+	assert.Nil(t, diff.HeroesAdded)
+}
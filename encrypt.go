@@ -0,0 +1,114 @@
+package deckstrings
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// The encrypted deckstring version supported by this package.
+const EncryptedVersion = 1
+
+// ErrDecryptionFailed is the cause of errors returned when an encrypted
+// deckstring can't be decrypted, because the key is wrong or the deckstring
+// was modified. Use errors.Cause to check for it.
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// EncodeEncrypted encodes a deck like Encode and encrypts it with AES-GCM,
+// producing an opaque code that only holders of key can decode with
+// DecodeEncrypted. The key must be 16, 24, or 32 bytes long, selecting
+// AES-128, AES-192, or AES-256. Each call uses a random nonce, so encrypting
+// the same deck twice gives different codes.
+//
+// The encrypted container is laid out as:
+//
+//	magic       0xFF 'E'
+//	version     EncryptedVersion
+//	nonce       12 random bytes
+//	ciphertext  the sealed deckstring payload, authenticated along with the
+//	            magic and version
+//
+// Encrypted deckstrings can't be imported into the game.
+func EncodeEncrypted(deck Deck, key []byte, opts ...EncodeOption) (encrypted string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring encrypt")
+		}
+	}()
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	var payload bytes.Buffer
+	if err := encodePayload(&payload, deck, newEncodeOptions(opts)); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	writeContainerHeader(&buf, containerEncrypted, EncryptedVersion)
+	header := buf.Len()
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	buf.Write(nonce)
+
+	sealed := aead.Seal(buf.Bytes(), nonce, payload.Bytes(), buf.Bytes()[:header])
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecodeEncrypted decrypts a deckstring created by EncodeEncrypted with the
+// same key and decodes it like Decode. If the key is wrong or the deckstring
+// was modified, errors.Cause of the returned error is ErrDecryptionFailed.
+func DecodeEncrypted(encrypted string, key []byte, opts ...DecodeOption) (deck Deck, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring decrypt")
+		}
+	}()
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return Deck{}, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return Deck{}, err
+	}
+
+	reader, err := readContainerHeader(data, containerEncrypted, EncryptedVersion)
+	if err != nil {
+		return Deck{}, err
+	}
+
+	header := len(data) - reader.Len()
+	if reader.Len() < aead.NonceSize()+aead.Overhead() {
+		return Deck{}, fmt.Errorf("ciphertext too short: %d bytes", reader.Len())
+	}
+
+	nonce := data[header : header+aead.NonceSize()]
+	payload, err := aead.Open(nil, nonce, data[header+aead.NonceSize():], data[:header])
+	if err != nil {
+		return Deck{}, ErrDecryptionFailed
+	}
+
+	deck, _, err = decode(bytes.NewReader(payload), newDecodeOptions(opts))
+	return deck, err
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
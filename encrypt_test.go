@@ -0,0 +1,70 @@
+package deckstrings_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeEncrypted(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck := MustDecode(deckstring)
+
+	for _, key := range [][]byte{testKey[:16], testKey[:24], testKey} {
+		encrypted, err := EncodeEncrypted(deck, key)
+		assert.Nil(t, err)
+		assert.NotContains(t, encrypted, deckstring[:8])
+
+		decoded, err := DecodeEncrypted(encrypted, key)
+		assert.Nil(t, err)
+		assert.Equal(t, deck, decoded)
+
+		// Encrypted deckstrings are not deckstrings.
+		_, err = Decode(encrypted)
+		assert.NotNil(t, err)
+	}
+
+	// Nonces are random.
+	a, _ := EncodeEncrypted(deck, testKey)
+	b, _ := EncodeEncrypted(deck, testKey)
+	assert.NotEqual(t, a, b)
+}
+
+func TestDecodeEncryptedWrongKey(t *testing.T) {
+	encrypted, err := EncodeEncrypted(MustDecode("AAEBAQcAAAQBAwIDAwMEAw=="), testKey)
+	assert.Nil(t, err)
+
+	_, err = DecodeEncrypted(encrypted, []byte("fedcba9876543210fedcba9876543210"))
+	assert.EqualError(t, err, "deckstring decrypt: decryption failed")
+	assert.Equal(t, ErrDecryptionFailed, errors.Cause(err))
+}
+
+func TestDecodeEncryptedTampered(t *testing.T) {
+	encrypted, err := EncodeEncrypted(MustDecode("AAEBAQcAAAQBAwIDAwMEAw=="), testKey)
+	assert.Nil(t, err)
+
+	data, _ := base64.StdEncoding.DecodeString(encrypted)
+	data[len(data)-1] ^= 1
+	_, err = DecodeEncrypted(base64.StdEncoding.EncodeToString(data), testKey)
+	assert.Equal(t, ErrDecryptionFailed, errors.Cause(err))
+}
+
+func TestEncryptedInvalid(t *testing.T) {
+	_, err := EncodeEncrypted(Deck{}, []byte("short"))
+	assert.EqualError(t, err, "deckstring encrypt: crypto/aes: invalid key size 5")
+
+	_, err = EncodeEncrypted(Deck{Cards: [][2]uint64{{1, 0}}}, testKey)
+	assert.EqualError(t, err, "deckstring encrypt: invalid card count for DBF ID 1")
+
+	_, err = DecodeEncrypted(base64.StdEncoding.EncodeToString([]byte{0xFF, 'E', 2}), testKey)
+	assert.EqualError(t, err, "deckstring decrypt: unsupported container version: 2")
+
+	_, err = DecodeEncrypted(base64.StdEncoding.EncodeToString([]byte{0xFF, 'E', 1, 0}), testKey)
+	assert.EqualError(t, err, "deckstring decrypt: ciphertext too short: 1 bytes")
+
+	_, err = DecodeEncrypted("AAEBAQcAAAQBAwIDAwMEAw==", testKey)
+	assert.EqualError(t, err, `deckstring decrypt: expected encrypted container`)
+}
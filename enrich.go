@@ -0,0 +1,47 @@
+package deckstrings
+
+import "cmp"
+
+// DeckCard is a card in a deck along with its metadata.
+type DeckCard struct {
+	CardInfo
+	Count uint64
+}
+
+// Enrich returns the cards in a deck along with their metadata, in the order
+// the game client lists them. See SortGameOrder. Duplicate entries for a card
+// are merged.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func Enrich(deck Deck, db *CardDB) ([]DeckCard, error) {
+	var cards []DeckCard
+	err := tally(Canonicalize(deck), db, func(card CardInfo, count uint64) {
+		cards = append(cards, DeckCard{CardInfo: card, Count: count})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	SortGameOrder(cards)
+	return cards, nil
+}
+
+// CompareGameOrder compares cards in the order the game client lists them in
+// a deck: by mana cost ascending, then by name. Cards with the same cost and
+// name, such as reprints, are ordered by DBF ID so the order is total. It
+// returns -1, 0, or +1 like cmp.Compare, for use with slices.SortFunc.
+func CompareGameOrder(a, b CardInfo) int {
+	if c := cmp.Compare(a.Cost, b.Cost); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.DBFID, b.DBFID)
+}
+
+// SortGameOrder sorts cards in the order the game client lists them. See
+// CompareGameOrder.
+func SortGameOrder(cards []DeckCard) {
+	SortCards(cards)
+}
@@ -0,0 +1,46 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrich(t *testing.T) {
+	db := testCardDB(t)
+
+	cards, err := Enrich(Deck{Cards: [][2]uint64{{581, 1}, {77, 1}, {315, 2}, {77, 1}}}, db)
+	assert.Nil(t, err)
+	if assert.Len(t, cards, 3) {
+		assert.Equal(t, "Fireball", cards[0].Name)
+		assert.Equal(t, uint64(2), cards[0].Count)
+		assert.Equal(t, "Polymorph", cards[1].Name)
+		assert.Equal(t, uint64(2), cards[1].Count)
+		assert.Equal(t, uint64(581), cards[2].DBFID)
+	}
+
+	_, err = Enrich(Deck{Cards: [][2]uint64{{999999, 1}}}, db)
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestCompareGameOrder(t *testing.T) {
+	fireball := CardInfo{DBFID: 315, Name: "Fireball", Cost: 4}
+	reprint := CardInfo{DBFID: 64678, Name: "Fireball", Cost: 4}
+	polymorph := CardInfo{DBFID: 77, Name: "Polymorph", Cost: 4}
+	frostbolt := CardInfo{DBFID: 662, Name: "Frostbolt", Cost: 2}
+
+	assert.Equal(t, -1, CompareGameOrder(frostbolt, fireball))
+	assert.Equal(t, -1, CompareGameOrder(fireball, polymorph))
+	assert.Equal(t, -1, CompareGameOrder(fireball, reprint))
+	assert.Equal(t, 1, CompareGameOrder(polymorph, frostbolt))
+	assert.Equal(t, 0, CompareGameOrder(fireball, fireball))
+
+	cards := []DeckCard{{CardInfo: polymorph}, {CardInfo: reprint}, {CardInfo: frostbolt}, {CardInfo: fireball}}
+	SortGameOrder(cards)
+	ids := make([]uint64, len(cards))
+	for i, card := range cards {
+		ids[i] = card.DBFID
+	}
+	assert.Equal(t, []uint64{662, 315, 64678, 77}, ids)
+}
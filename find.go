@@ -0,0 +1,88 @@
+package deckstrings
+
+// DeckstringMatch is a deckstring found in text by FindDeckstrings. Start and
+// End are the byte offsets of the deckstring in the text, so
+// text[Start:End] == Deckstring.
+type DeckstringMatch struct {
+	Start      int
+	End        int
+	Deckstring string
+	Deck       Deck
+}
+
+// minDeckstringLength is the length of the shortest deckstring: a deck with
+// no heroes and no cards.
+const minDeckstringLength = len("AAEBAAAAAA==")
+
+// FindDeckstrings scans text such as chat logs, Reddit posts, or forum
+// content for deck codes, returning every valid deckstring found in order of
+// appearance. Candidates are runs of base64 characters, including codes
+// embedded in URL paths; those that fail to decode are skipped. Options are
+// passed to Decode for each candidate.
+func FindDeckstrings(text string, opts ...DecodeOption) []DeckstringMatch {
+	var matches []DeckstringMatch
+
+	for start := 0; start < len(text); {
+		if !isBase64Char(text[start]) {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(text) && isBase64Char(text[end]) {
+			end++
+		}
+		for i := 0; i < 2 && end < len(text) && text[end] == '='; i++ {
+			end++
+		}
+
+		if match := findInRun(text, start, end, opts); match != nil {
+			matches = append(matches, *match)
+		}
+		start = end
+	}
+
+	return matches
+}
+
+// findInRun finds a deckstring in text[start:end], a run of base64
+// characters. Since '/' is a base64 character, a run may include a URL path
+// before or after a deckstring, so candidates also begin after each '/' and
+// may exclude trailing '/' characters.
+func findInRun(text string, start, end int, opts []DecodeOption) *DeckstringMatch {
+	for i := start; end-i >= minDeckstringLength; i++ {
+		if i != start && text[i-1] != '/' {
+			continue
+		}
+
+		last := end
+		for last > i && text[last-1] == '/' {
+			last--
+		}
+
+		stops := []int{last}
+		if last != end {
+			stops = append(stops, end)
+		}
+
+		for _, stop := range stops {
+			candidate := text[i:stop]
+			if len(candidate) < minDeckstringLength {
+				continue
+			}
+
+			deck, err := Decode(candidate, opts...)
+			if err != nil {
+				continue
+			}
+
+			return &DeckstringMatch{Start: i, End: stop, Deckstring: candidate, Deck: deck}
+		}
+	}
+
+	return nil
+}
+
+func isBase64Char(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '+' || c == '/'
+}
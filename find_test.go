@@ -0,0 +1,49 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDeckstrings(t *testing.T) {
+	hunter := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	priest := "AAECAZICCPIF+Az5DK6rAuC7ApS9AsnHApnTAgtAX/4BxAbkCLS7Asu8As+8At2+AqDNAofOAgA="
+
+	text := "Check out my deck: " + hunter + " (65% winrate)\n" +
+		"Also try https://example.com/decks/" + priest + "/ and AAAA or hello/world."
+
+	matches := FindDeckstrings(text)
+	assert.Len(t, matches, 2)
+
+	assert.Equal(t, hunter, matches[0].Deckstring)
+	assert.Equal(t, hunter, text[matches[0].Start:matches[0].End])
+	assert.Equal(t, MustDecode(hunter), matches[0].Deck)
+
+	assert.Equal(t, priest, matches[1].Deckstring)
+	assert.Equal(t, priest, text[matches[1].Start:matches[1].End])
+	assert.Equal(t, MustDecode(priest), matches[1].Deck)
+}
+
+func TestFindDeckstringsPath(t *testing.T) {
+	// A deckstring that needs no padding followed by a slash.
+	text := "/decks/AAEBAQcAAAAA/"
+
+	matches := FindDeckstrings(text)
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "AAEBAQcAAAAA", matches[0].Deckstring)
+		assert.Equal(t, 7, matches[0].Start)
+	}
+}
+
+func TestFindDeckstringsOptions(t *testing.T) {
+	text := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	assert.Len(t, FindDeckstrings(text), 1)
+	assert.Len(t, FindDeckstrings(text, RequireFormat(FormatWild)), 0)
+}
+
+func TestFindDeckstringsNone(t *testing.T) {
+	assert.Nil(t, FindDeckstrings(""))
+	assert.Nil(t, FindDeckstrings("no deck codes here, just words and AAAAAAAAAAAA"))
+}
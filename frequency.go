@@ -0,0 +1,129 @@
+package deckstrings
+
+import "sort"
+
+// CardFrequency summarizes how often a card is played across a group of decks.
+//
+// Decks is the number of decks including the card and Copies is the total
+// number of copies across those decks. InclusionRate is the fraction of decks
+// in the group including the card, and AverageCopies is the average number of
+// copies in decks including the card.
+type CardFrequency struct {
+	DBFID         uint64
+	Decks         int
+	Copies        uint64
+	InclusionRate float64
+	AverageCopies float64
+}
+
+// FrequencyAggregator accumulates card play statistics over a stream of decks,
+// optionally partitioned into groups such as class or format. Only per-card
+// totals are retained, so any number of decks can be added. A
+// FrequencyAggregator is not safe for concurrent use.
+type FrequencyAggregator struct {
+	groupBy func(Deck) string
+	groups  map[string]*frequencyGroup
+}
+
+type frequencyGroup struct {
+	decks  int
+	counts map[uint64]*CardFrequency
+}
+
+// NewFrequencyAggregator creates an aggregator that partitions decks into
+// groups named by groupBy, e.g. GroupByFormat. If groupBy is nil, every deck is
+// added to a single group named "".
+func NewFrequencyAggregator(groupBy func(Deck) string) *FrequencyAggregator {
+	if groupBy == nil {
+		groupBy = func(Deck) string { return "" }
+	}
+	return &FrequencyAggregator{groupBy: groupBy, groups: make(map[string]*frequencyGroup)}
+}
+
+// GroupByFormat groups decks by format name, e.g. "Standard".
+func GroupByFormat(deck Deck) string {
+	return deck.Format.String()
+}
+
+// GroupByClass returns a grouping function that groups decks by the class of
+// their first hero as found in db, e.g. "MAGE". Decks without heroes or with
+// heroes unknown to db are grouped under "".
+func GroupByClass(db *CardDB) func(Deck) string {
+	return func(deck Deck) string {
+		if len(deck.Heroes) == 0 {
+			return ""
+		}
+		hero, _ := db.Card(deck.Heroes[0])
+		return hero.Class
+	}
+}
+
+// Add adds a deck to the aggregate statistics.
+func (a *FrequencyAggregator) Add(deck Deck) {
+	name := a.groupBy(deck)
+	group, ok := a.groups[name]
+	if !ok {
+		group = &frequencyGroup{counts: make(map[uint64]*CardFrequency)}
+		a.groups[name] = group
+	}
+
+	group.decks++
+	for dbfID, count := range cardCountsByID(deck.Cards) {
+		frequency, ok := group.counts[dbfID]
+		if !ok {
+			frequency = &CardFrequency{DBFID: dbfID}
+			group.counts[dbfID] = frequency
+		}
+		frequency.Decks++
+		frequency.Copies += count
+	}
+}
+
+// Groups returns the names of all groups with at least one deck, in ascending order.
+func (a *FrequencyAggregator) Groups() []string {
+	names := make([]string, 0, len(a.groups))
+	for name := range a.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decks returns the number of decks added to the group.
+func (a *FrequencyAggregator) Decks(group string) int {
+	if g, ok := a.groups[group]; ok {
+		return g.decks
+	}
+	return 0
+}
+
+// Frequencies returns statistics for every card played in the group, ordered
+// by inclusion rate descending, then by average copies descending, then by DBF
+// ID ascending.
+func (a *FrequencyAggregator) Frequencies(group string) []CardFrequency {
+	g, ok := a.groups[group]
+	if !ok {
+		return nil
+	}
+
+	frequencies := make([]CardFrequency, 0, len(g.counts))
+	for _, frequency := range g.counts {
+		f := *frequency
+		f.InclusionRate = float64(f.Decks) / float64(g.decks)
+		f.AverageCopies = float64(f.Copies) / float64(f.Decks)
+		frequencies = append(frequencies, f)
+	}
+
+	sort.Slice(frequencies, func(i, j int) bool {
+		p, q := frequencies[i], frequencies[j]
+		if p.Decks != q.Decks {
+			return p.Decks > q.Decks
+		}
+		if p.AverageCopies != q.AverageCopies {
+			return p.AverageCopies > q.AverageCopies
+		}
+		return p.DBFID < q.DBFID
+	})
+
+	return frequencies
+}
@@ -0,0 +1,52 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrequencyAggregator(t *testing.T) {
+	aggregator := NewFrequencyAggregator(nil)
+	aggregator.Add(Deck{Cards: [][2]uint64{{1, 2}, {2, 1}}})
+	aggregator.Add(Deck{Cards: [][2]uint64{{1, 1}, {3, 2}}})
+	aggregator.Add(Deck{Cards: [][2]uint64{{1, 2}, {2, 1}, {2, 1}}})
+	aggregator.Add(Deck{Cards: [][2]uint64{{4, 1}}})
+
+	assert.Equal(t, []string{""}, aggregator.Groups())
+	assert.Equal(t, 4, aggregator.Decks(""))
+	assert.Equal(t, []CardFrequency{
+		{DBFID: 1, Decks: 3, Copies: 5, InclusionRate: 0.75, AverageCopies: 5.0 / 3.0},
+		{DBFID: 2, Decks: 2, Copies: 3, InclusionRate: 0.5, AverageCopies: 1.5},
+		{DBFID: 3, Decks: 1, Copies: 2, InclusionRate: 0.25, AverageCopies: 2},
+		{DBFID: 4, Decks: 1, Copies: 1, InclusionRate: 0.25, AverageCopies: 1},
+	}, aggregator.Frequencies(""))
+
+	assert.Nil(t, aggregator.Frequencies("missing"))
+	assert.Equal(t, 0, aggregator.Decks("missing"))
+}
+
+func TestFrequencyAggregatorByFormat(t *testing.T) {
+	aggregator := NewFrequencyAggregator(GroupByFormat)
+	aggregator.Add(Deck{Format: FormatStandard, Cards: [][2]uint64{{1, 2}}})
+	aggregator.Add(Deck{Format: FormatWild, Cards: [][2]uint64{{2, 2}}})
+	aggregator.Add(Deck{Format: FormatWild, Cards: [][2]uint64{{2, 1}}})
+
+	assert.Equal(t, []string{"Standard", "Wild"}, aggregator.Groups())
+	assert.Equal(t, 2, aggregator.Decks("Wild"))
+
+	frequencies := aggregator.Frequencies("Wild")
+	assert.Len(t, frequencies, 1)
+	assert.Equal(t, 1.0, frequencies[0].InclusionRate)
+	assert.Equal(t, 1.5, frequencies[0].AverageCopies)
+}
+
+func TestFrequencyAggregatorByClass(t *testing.T) {
+	aggregator := NewFrequencyAggregator(GroupByClass(testCardDB(t)))
+	aggregator.Add(testMageDeck())
+	aggregator.Add(Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{1023, 2}}})
+	aggregator.Add(Deck{Cards: [][2]uint64{{1, 1}}})
+
+	assert.Equal(t, []string{"", "MAGE", "WARRIOR"}, aggregator.Groups())
+}
@@ -0,0 +1,30 @@
+package deckstrings_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRoundTrip(t *testing.T) {
+	roundTrip := func(deck Deck) bool {
+		deckstring, err := Encode(deck)
+		if err != nil {
+			return false
+		}
+		decoded, err := Decode(deckstring)
+		return err == nil && assert.ObjectsAreEqual(deck, decoded)
+	}
+
+	assert.Nil(t, quick.Check(roundTrip, nil))
+}
+
+func TestGenerateKnownFormat(t *testing.T) {
+	known := func(deck Deck) bool {
+		return deck.Format.Known()
+	}
+
+	assert.Nil(t, quick.Check(known, nil))
+}
@@ -1,8 +1,14 @@
 module github.com/schmich/deckstrings
 
+go 1.23.0
+
 require (
-	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pkg/errors v0.8.0
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.2.2
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 )
@@ -0,0 +1,48 @@
+package deckstrings
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sort"
+)
+
+// Deck and the other value types in this package are plain structs of
+// exported fields and encode with encoding/gob as-is. They are registered so
+// they can also be carried in interface values, e.g. as RPC arguments or
+// entries in a gob-backed cache of interface{}.
+func init() {
+	gob.Register(Deck{})
+	gob.Register(DecodeInfo{})
+	gob.Register(DeckDiff{})
+	gob.Register(CardInfo{})
+	gob.Register(Archetype{})
+	gob.Register(&CardDB{})
+}
+
+// GobEncode implements gob.GobEncoder. The database is encoded as its list of
+// cards ordered by DBF ID.
+func (db *CardDB) GobEncode() ([]byte, error) {
+	cards := make([]CardInfo, 0, len(db.cards))
+	for _, card := range db.cards {
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].DBFID < cards[j].DBFID
+	})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cards); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (db *CardDB) GobDecode(data []byte) error {
+	var cards []CardInfo
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cards); err != nil {
+		return err
+	}
+	*db = *NewCardDB(cards)
+	return nil
+}
@@ -0,0 +1,57 @@
+package deckstrings_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func gobRoundTrip(t *testing.T, in, out interface{}) {
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(in))
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(out))
+}
+
+func TestGobDeck(t *testing.T) {
+	deck := testMageDeck()
+
+	var decoded Deck
+	gobRoundTrip(t, deck, &decoded)
+	assert.Equal(t, deck, decoded)
+}
+
+func TestGobInterface(t *testing.T) {
+	type entry struct {
+		Value interface{}
+	}
+
+	deck := testMageDeck()
+
+	var decoded entry
+	gobRoundTrip(t, entry{Value: deck}, &decoded)
+	assert.Equal(t, deck, decoded.Value)
+}
+
+func TestGobDecodeInfo(t *testing.T) {
+	_, info, err := DecodeWithInfo("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+
+	var decoded DecodeInfo
+	gobRoundTrip(t, info, &decoded)
+	assert.Equal(t, info, decoded)
+}
+
+func TestGobCardDB(t *testing.T) {
+	db := testCardDB(t)
+
+	var decoded CardDB
+	gobRoundTrip(t, db, &decoded)
+	assert.Equal(t, db.Len(), decoded.Len())
+
+	card, ok := decoded.Card(315)
+	assert.True(t, ok)
+	assert.Equal(t, "Fireball", card.Name)
+}
@@ -0,0 +1,56 @@
+package deckstrings
+
+import (
+	"hash"
+	"hash/fnv"
+)
+
+// Hash returns a 64-bit FNV-1a digest of the deck's canonical form. Decks that
+// are Equal have the same hash regardless of the order of their heroes and
+// cards, making the hash suitable as a deduplication or sharding key.
+//
+// The digest is stable across releases: it covers the format, the sorted
+// heroes, and the merged, sorted cards, each written as a uvarint, followed by
+// the version if it is not the current Version. Decks with a Version of 0 or
+// Version therefore hash as they did before versions were tracked.
+//
+// Sideboards are not decoded into a Deck, so decks that differ only in their
+// sideboards have the same hash. To tell them apart, key on the hash together
+// with DecodeInfo.Trailing, which holds the undecoded sideboard data.
+func Hash(deck Deck) uint64 {
+	h := fnv.New64a()
+	writeCanonical(h, deck)
+	return h.Sum64()
+}
+
+// Hash128 returns a 128-bit FNV-1a digest of the deck's canonical form. See
+// Hash, including its handling of sideboards. The wider digest makes
+// collisions negligible for very large corpora.
+func Hash128(deck Deck) [16]byte {
+	var sum [16]byte
+	h := fnv.New128a()
+	writeCanonical(h, deck)
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// writeCanonical writes the canonical form of deck to h. The lengths of the
+// hero and card lists are included so that different splits of the same
+// values can't collide. Writes to a hash.Hash never fail.
+func writeCanonical(h hash.Hash, deck Deck) {
+	deck = Canonicalize(deck)
+	varint := &varintWriter{h}
+
+	varint.Write(uint64(deck.Format))
+	varint.Write(uint64(len(deck.Heroes)))
+	varint.WriteMany(deck.Heroes)
+
+	varint.Write(uint64(len(deck.Cards)))
+	for _, card := range deck.Cards {
+		varint.WriteMany(card[:])
+	}
+
+	if version := deck.version(); version != Version {
+		varint.Write(version)
+	}
+}
@@ -0,0 +1,58 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashOrderIndependent(t *testing.T) {
+	a := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31, 7},
+		Cards:  [][2]uint64{{455, 1}, {141, 2}},
+	}
+	b := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{7, 31},
+		Cards:  [][2]uint64{{141, 1}, {455, 1}, {141, 1}},
+	}
+
+	assert.Equal(t, Hash(a), Hash(b))
+	assert.Equal(t, Hash128(a), Hash128(b))
+}
+
+func TestHashDistinct(t *testing.T) {
+	deck := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{141, 2}, {455, 1}},
+	}
+	others := []Deck{
+		{Format: FormatWild, Heroes: []uint64{31}, Cards: [][2]uint64{{141, 2}, {455, 1}}},
+		{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{141, 2}, {455, 1}}},
+		{Format: FormatStandard, Heroes: []uint64{31}, Cards: [][2]uint64{{141, 1}, {455, 2}}},
+		{Format: FormatStandard, Heroes: []uint64{31, 141}, Cards: [][2]uint64{{455, 1}}},
+		{Format: FormatStandard, Heroes: []uint64{31}, Cards: [][2]uint64{{141, 2}, {455, 1}}, Version: 2},
+	}
+
+	for _, other := range others {
+		assert.NotEqual(t, Hash(deck), Hash(other))
+		assert.NotEqual(t, Hash128(deck), Hash128(other))
+	}
+}
+
+func TestHashStable(t *testing.T) {
+	deck := Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{31},
+		Cards:  [][2]uint64{{141, 2}, {455, 1}},
+	}
+	// Changing this value breaks existing dedup and shard keys.
+	assert.Equal(t, uint64(0x3a309b38444a38), Hash(deck))
+
+	// Explicitly setting the current version doesn't change the hash.
+	deck.Version = Version
+	assert.Equal(t, uint64(0x3a309b38444a38), Hash(deck))
+}
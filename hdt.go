@@ -0,0 +1,170 @@
+package deckstrings
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// HDTDeck is a deck from a Hearthstone Deck Tracker library. Err is set if the
+// deck could not be converted, e.g. because it lists a card missing from the
+// card database, in which case Deck is empty.
+type HDTDeck struct {
+	Name  string
+	Class string
+	Deck  Deck
+	Err   error
+}
+
+// hdtDecks mirrors HDT's saved deck library, PlayerDecks.xml.
+type hdtDecks struct {
+	XMLName xml.Name  `xml:"Decks"`
+	Decks   []hdtDeck `xml:"Deck"`
+}
+
+type hdtDeck struct {
+	Name  string    `xml:"Name"`
+	Class string    `xml:"Class"`
+	Cards []hdtCard `xml:"Cards>Card"`
+}
+
+// hdtClasses maps classes as named in HearthstoneJSON to HDT's class names.
+var hdtClasses = map[string]string{
+	"DEATHKNIGHT": "DeathKnight",
+	"DEMONHUNTER": "DemonHunter",
+	"DRUID":       "Druid",
+	"HUNTER":      "Hunter",
+	"MAGE":        "Mage",
+	"PALADIN":     "Paladin",
+	"PRIEST":      "Priest",
+	"ROGUE":       "Rogue",
+	"SHAMAN":      "Shaman",
+	"WARLOCK":     "Warlock",
+	"WARRIOR":     "Warrior",
+}
+
+type hdtCard struct {
+	ID    string `xml:"Id"`
+	Count uint64 `xml:"Count"`
+}
+
+// ReadHDTDecks reads the decks in a Hearthstone Deck Tracker library, the
+// PlayerDecks.xml file in HDT's data directory, so a tracker library can be
+// batch-converted to deckstrings. HDT lists cards by card ID, e.g. "CS2_029",
+// which are resolved to DBF IDs with db.
+//
+// Each deck's hero is the default hero for its class (see DefaultHeroes) and
+// its format is FormatWild, since HDT does not record either in a way that
+// maps to deckstrings. Decks that can't be converted are returned with Err
+// set; an error is returned only if the XML can't be read.
+func ReadHDTDecks(reader io.Reader, db *CardDB) ([]HDTDeck, error) {
+	var library hdtDecks
+	if err := xml.NewDecoder(reader).Decode(&library); err != nil {
+		return nil, errors.Wrap(err, "HDT decks read")
+	}
+
+	decks := make([]HDTDeck, 0, len(library.Decks))
+	for _, entry := range library.Decks {
+		deck, err := entry.deck(db)
+		if err != nil {
+			err = errors.Wrapf(err, "HDT deck %q", entry.Name)
+		}
+		decks = append(decks, HDTDeck{Name: entry.Name, Class: entry.Class, Deck: deck, Err: err})
+	}
+
+	return decks, nil
+}
+
+func (d hdtDeck) deck(db *CardDB) (Deck, error) {
+	hero, ok := DefaultHeroes[strings.ToUpper(d.Class)]
+	if !ok {
+		return Deck{}, fmt.Errorf("unknown class: %q", d.Class)
+	}
+
+	counts := make(map[uint64]uint64, len(d.Cards))
+	for _, card := range d.Cards {
+		dbfID, err := db.lookupID(card.ID)
+		if err != nil {
+			return Deck{}, err
+		}
+
+		// HDT omits the count of single copies.
+		count := card.Count
+		if count == 0 {
+			count = 1
+		}
+		counts[dbfID] += count
+	}
+
+	return FromCardsMap(FormatWild, []uint64{hero}, counts), nil
+}
+
+// WriteHDTDecks writes decks as a Hearthstone Deck Tracker library in the
+// format of PlayerDecks.xml, e.g. to populate a tracker from a list of
+// deckstrings. Cards are written by card ID, resolved from DBF IDs with db.
+//
+// Each deck's Name is written as is. If Class is empty, it is derived from the
+// deck's first hero, which must be in db or be one of DefaultHeroes. Err is
+// ignored. Returns an UnknownCardError if a card in a deck is not in db.
+func WriteHDTDecks(writer io.Writer, decks []HDTDeck, db *CardDB) error {
+	library := hdtDecks{Decks: make([]hdtDeck, 0, len(decks))}
+	for _, deck := range decks {
+		entry, err := newHDTDeck(deck, db)
+		if err != nil {
+			return errors.Wrapf(err, "HDT deck %q", deck.Name)
+		}
+		library.Decks = append(library.Decks, entry)
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(library); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+func newHDTDeck(deck HDTDeck, db *CardDB) (hdtDeck, error) {
+	entry := hdtDeck{Name: deck.Name, Class: deck.Class}
+	if entry.Class == "" {
+		class, err := heroClass(deck.Deck, db)
+		if err != nil {
+			return hdtDeck{}, err
+		}
+		entry.Class = class
+	}
+
+	for _, card := range Canonicalize(deck.Deck).Cards {
+		if _, err := db.lookup(card[0]); err != nil {
+			return hdtDeck{}, err
+		}
+		id, ok := db.CardID(card[0])
+		if !ok {
+			return hdtDeck{}, fmt.Errorf("no card ID for DBF ID %d", card[0])
+		}
+		entry.Cards = append(entry.Cards, hdtCard{ID: id, Count: card[1]})
+	}
+
+	return entry, nil
+}
+
+// heroClass returns the HDT class name of the deck's first hero.
+func heroClass(deck Deck, db *CardDB) (string, error) {
+	if len(deck.Heroes) == 0 {
+		return "", fmt.Errorf("deck has no heroes")
+	}
+
+	if class, ok := hdtClasses[HeroClass(deck, db)]; ok {
+		return class, nil
+	}
+	return "", fmt.Errorf("unknown class for hero %d", deck.Heroes[0])
+}
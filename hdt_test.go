@@ -0,0 +1,103 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+const hdtLibrary = `<?xml version="1.0" encoding="utf-8"?>
+<Decks xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+  <Deck>
+    <Name>Freeze Mage</Name>
+    <Class>Mage</Class>
+    <Cards>
+      <Card>
+        <Id>CS2_029</Id>
+        <Count>2</Count>
+      </Card>
+      <Card>
+        <Id>EX1_561</Id>
+      </Card>
+    </Cards>
+    <Tags />
+    <IsArenaDeck>false</IsArenaDeck>
+  </Deck>
+  <Deck>
+    <Name>Broken</Name>
+    <Class>Warrior</Class>
+    <Cards>
+      <Card>
+        <Id>NOPE_001</Id>
+        <Count>1</Count>
+      </Card>
+    </Cards>
+  </Deck>
+</Decks>`
+
+func TestReadHDTDecks(t *testing.T) {
+	decks, err := ReadHDTDecks(strings.NewReader(hdtLibrary), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Len(t, decks, 2)
+
+	assert.Equal(t, HDTDeck{
+		Name:  "Freeze Mage",
+		Class: "Mage",
+		Deck: Deck{
+			Format: FormatWild,
+			Heroes: []uint64{637},
+			Cards:  [][2]uint64{{315, 2}, {581, 1}},
+		},
+	}, decks[0])
+
+	assert.Equal(t, "Broken", decks[1].Name)
+	assert.EqualError(t, decks[1].Err, `HDT deck "Broken": unknown card ID: "NOPE_001"`)
+	assert.Equal(t, UnknownCardIDError{ID: "NOPE_001"}, errors.Cause(decks[1].Err))
+}
+
+func TestReadHDTDecksInvalid(t *testing.T) {
+	_, err := ReadHDTDecks(strings.NewReader("<Decks>"), testCardDB(t))
+	assert.NotNil(t, err)
+
+	decks, err := ReadHDTDecks(strings.NewReader("<Decks><Deck><Name>X</Name><Class>Bard</Class></Deck></Decks>"), testCardDB(t))
+	assert.Nil(t, err)
+	assert.EqualError(t, decks[0].Err, `HDT deck "X": unknown class: "Bard"`)
+}
+
+func TestWriteHDTDecks(t *testing.T) {
+	db := testCardDB(t)
+	decks := []HDTDeck{
+		{Name: "Mage", Deck: testMageDeck()},
+		{Name: "Warrior", Class: "Warrior", Deck: Deck{Heroes: []uint64{99999}, Cards: [][2]uint64{{401, 2}}}},
+	}
+
+	var b strings.Builder
+	err := WriteHDTDecks(&b, decks, db)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(b.String(), `<?xml version="1.0" encoding="UTF-8"?>`+"\n<Decks>\n  <Deck>\n    <Name>Mage</Name>\n    <Class>Mage</Class>"))
+	assert.Contains(t, b.String(), "<Card>\n        <Id>CS2_029</Id>\n        <Count>2</Count>\n      </Card>")
+
+	// Round trip.
+	read, err := ReadHDTDecks(strings.NewReader(b.String()), db)
+	assert.Nil(t, err)
+	assert.Len(t, read, 2)
+	assert.Equal(t, testMageDeck(), read[0].Deck)
+	assert.Equal(t, "Warrior", read[1].Class)
+	assert.Equal(t, [][2]uint64{{401, 2}}, read[1].Deck.Cards)
+}
+
+func TestWriteHDTDecksInvalid(t *testing.T) {
+	db := testCardDB(t)
+
+	err := WriteHDTDecks(&strings.Builder{}, []HDTDeck{{Name: "A", Deck: Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{999999, 1}}}}}, db)
+	assert.EqualError(t, err, `HDT deck "A": unknown card: DBF ID 999999`)
+
+	err = WriteHDTDecks(&strings.Builder{}, []HDTDeck{{Name: "B", Deck: Deck{Heroes: []uint64{99999}}}}, db)
+	assert.EqualError(t, err, `HDT deck "B": unknown class for hero 99999`)
+
+	err = WriteHDTDecks(&strings.Builder{}, []HDTDeck{{Name: "C"}}, db)
+	assert.EqualError(t, err, `HDT deck "C": deck has no heroes`)
+}
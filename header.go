@@ -0,0 +1,132 @@
+package deckstrings
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Header is the fixed prefix of a deckstring preceding the card list.
+type Header struct {
+	Version   uint64
+	Format    Format
+	HeroCount uint64
+}
+
+// errStop is returned by parse visitors to stop parsing early.
+var errStop = errors.New("stop")
+
+// ParseHeader reads only the reserved byte, version, format, and hero count of
+// a deckstring. It is much cheaper than Decode and useful for triaging or
+// routing large batches of deckstrings. The rest of the deckstring is not
+// validated.
+//
+// Compressed deckstrings are inflated as in Decode. A deckstring whose version
+// has a codec registered with RegisterCodec is decoded in full by that codec,
+// so it gets none of the savings. Of the decode options, only
+// MaxPayloadLength, DecodeNewerVersions, and AllowReserved apply.
+func ParseHeader(deckstring string, opts ...DecodeOption) (header Header, err error) {
+	deck, err := parseDeckstring(deckstring, newDecodeOptions(opts), func(field Field) error {
+		switch field.Kind {
+		case FieldVersion:
+			header.Version = field.Value
+		case FieldFormat:
+			header.Format = Format(field.Value)
+		case FieldHeroCount:
+			header.HeroCount = field.Value
+			return errStop
+		}
+		return nil
+	})
+
+	if deck != nil {
+		return Header{Version: deck.Version, Format: deck.Format, HeroCount: uint64(len(deck.Heroes))}, nil
+	}
+	if err != errStop {
+		return Header{}, errors.Wrap(err, "deckstring header")
+	}
+	return header, nil
+}
+
+// PeekFormat returns the format of a deckstring without decoding its cards.
+// See ParseHeader.
+func PeekFormat(deckstring string, opts ...DecodeOption) (Format, error) {
+	header, err := ParseHeader(deckstring, opts...)
+	return header.Format, err
+}
+
+// DecodeHeroes decodes only the heroes of a deckstring, stopping before the
+// card list. This lets UIs listing many decks resolve classes cheaply. Heroes
+// are ordered by DBF ID ascending as in Decode. The card list is not
+// validated. Deckstrings and options are handled as in ParseHeader.
+func DecodeHeroes(deckstring string, opts ...DecodeOption) (heroes []uint64, err error) {
+	var count uint64
+	deck, err := parseDeckstring(deckstring, newDecodeOptions(opts), func(field Field) error {
+		switch field.Kind {
+		case FieldHeroCount:
+			count = field.Value
+			heroes = make([]uint64, 0, min(count, 4))
+		case FieldHero:
+			heroes = append(heroes, field.Value)
+		default:
+			return nil
+		}
+		if uint64(len(heroes)) == count {
+			return errStop
+		}
+		return nil
+	})
+
+	if deck != nil {
+		heroes = append([]uint64{}, deck.Heroes...)
+	} else if err != errStop {
+		return nil, errors.Wrap(err, "deckstring decode heroes")
+	}
+
+	sort.Slice(heroes, func(i, j int) bool { return heroes[i] < heroes[j] })
+	return heroes, nil
+}
+
+// CountCards returns the total number of cards and the number of card entries
+// in a deckstring without building the card list, e.g. to quickly check for
+// complete 30 card decks at scale. Each card is normally listed once, so the
+// number of entries is the number of distinct cards; a card listed more than
+// once is counted once per entry. Deckstrings and options are handled as in
+// ParseHeader, and deckstrings whose counts overflow a uint64 are rejected.
+func CountCards(deckstring string, opts ...DecodeOption) (total uint64, distinct int, err error) {
+	add := func(count uint64) error {
+		if count > math.MaxUint64-total {
+			return fmt.Errorf("total card count overflows")
+		}
+		total += count
+		return nil
+	}
+
+	deck, err := parseDeckstring(deckstring, newDecodeOptions(opts), func(field Field) error {
+		switch {
+		case field.Kind == FieldCard:
+			distinct++
+			if field.Group < 3 {
+				return add(uint64(field.Group))
+			}
+		case field.Kind == FieldCount:
+			return add(field.Value)
+		}
+		return nil
+	})
+
+	if deck != nil {
+		for _, card := range deck.Cards {
+			if err = add(card[1]); err != nil {
+				break
+			}
+		}
+		distinct = len(deck.Cards)
+	}
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "deckstring count cards")
+	}
+	return total, distinct, nil
+}
@@ -0,0 +1,140 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeader(t *testing.T) {
+	header, err := ParseHeader("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Equal(t, Header{Version: 1, Format: FormatStandard, HeroCount: 1}, header)
+}
+
+func TestParseHeaderIgnoresCards(t *testing.T) {
+	// The card groups are truncated.
+	header, err := ParseHeader("AAEBAQcB")
+	assert.Nil(t, err)
+	assert.Equal(t, Header{Version: 1, Format: FormatWild, HeroCount: 1}, header)
+
+	_, err = Decode("AAEBAQcB")
+	assert.NotNil(t, err)
+}
+
+func TestParseHeaderInvalid(t *testing.T) {
+	_, err := ParseHeader("AAIAAAAAAA==")
+	assert.EqualError(t, err, "deckstring header: unsupported version: 2")
+
+	_, err = ParseHeader("AAE")
+	assert.NotNil(t, err)
+
+	_, err = ParseHeader("")
+	assert.NotNil(t, err)
+}
+
+func TestParseHeaderDecodeOptions(t *testing.T) {
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+
+	header, err := ParseHeader(MustEncode(researchDeck(), Compress()))
+	assert.Nil(t, err)
+	assert.Equal(t, Header{Version: 1, Format: FormatWild, HeroCount: 1}, header)
+
+	header, err = ParseHeader(MustEncode(deck, EncodeVersion(99)))
+	assert.Nil(t, err)
+	assert.Equal(t, Header{Version: 99, Format: FormatStandard, HeroCount: 1}, header)
+
+	reserved := MustEncode(deck, EncodeReserved(5))
+	_, err = ParseHeader(reserved)
+	assert.EqualError(t, err, "deckstring header: unexpected reserved byte: 5")
+
+	header, err = ParseHeader(reserved, AllowReserved())
+	assert.Nil(t, err)
+	assert.Equal(t, Header{Version: 1, Format: FormatStandard, HeroCount: 1}, header)
+
+	header, err = ParseHeader("AAIBAQcAAAA=", DecodeNewerVersions())
+	assert.Nil(t, err)
+	assert.Equal(t, Header{Version: 2, Format: FormatWild, HeroCount: 1}, header)
+
+	_, err = ParseHeader(MustEncode(deck), MaxPayloadLength(3))
+	assert.NotNil(t, err)
+}
+
+func TestPeekFormat(t *testing.T) {
+	format, err := PeekFormat("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatStandard, format)
+}
+
+func TestDecodeHeroes(t *testing.T) {
+	heroes, err := DecodeHeroes("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{31}, heroes)
+
+	// Unsorted heroes, no cards.
+	heroes, err = DecodeHeroes("AAEAAgIB")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2}, heroes)
+
+	heroes, err = DecodeHeroes("AAEAAA==")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{}, heroes)
+}
+
+func TestDecodeHeroesDecodeOptions(t *testing.T) {
+	heroes, err := DecodeHeroes(MustEncode(researchDeck(), Compress()))
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{637}, heroes)
+
+	deck := Deck{Format: FormatWild, Heroes: []uint64{7, 1}, Cards: [][2]uint64{{5, 1}}}
+	heroes, err = DecodeHeroes(MustEncode(deck, EncodeVersion(99)))
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 7}, heroes)
+}
+
+func TestDecodeHeroesInvalid(t *testing.T) {
+	// Two heroes declared, one present.
+	_, err := DecodeHeroes("AAEAAgI=")
+	assert.NotNil(t, err)
+
+	_, err = DecodeHeroes("AAIAAAAAAA==")
+	assert.NotNil(t, err)
+}
+
+func TestCountCards(t *testing.T) {
+	total, distinct, err := CountCards("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(30), total)
+	assert.Equal(t, 18, distinct)
+
+	// Explicit counts.
+	total, distinct, err = CountCards("AAEAAAAACAEDAgMDAwQEBQQGCgdkCOgH")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1127), total)
+	assert.Equal(t, 8, distinct)
+}
+
+func TestCountCardsInvalid(t *testing.T) {
+	_, _, err := CountCards("AAEBAQcB")
+	assert.NotNil(t, err)
+}
+
+func TestCountCardsDecodeOptions(t *testing.T) {
+	total, distinct, err := CountCards(MustEncode(researchDeck(), Compress()))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(600), total)
+	assert.Equal(t, 400, distinct)
+
+	deck := MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	total, distinct, err = CountCards(MustEncode(deck, EncodeVersion(99)))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(30), total)
+	assert.Equal(t, 18, distinct)
+}
+
+func TestCountCardsOverflow(t *testing.T) {
+	// Two cards with explicit counts of 2^64-1 and 1.
+	_, _, err := CountCards("AAEAAAAAAgH///////////8BAgE=")
+	assert.EqualError(t, err, "deckstring count cards: total card count overflows")
+}
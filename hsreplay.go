@@ -0,0 +1,75 @@
+package deckstrings
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// hsreplayAlphabet is the alphabet HSReplay.net encodes deck digests in: the
+// default alphabet of the Python shortuuid library, which omits characters
+// that are easily confused, such as 0, O, 1, I, and l.
+const hsreplayAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// hsreplayIDLength is the length of a deck ID. 22 base 57 digits hold any
+// 128-bit digest.
+const hsreplayIDLength = 22
+
+// HSReplayDigest returns the digest HSReplay.net identifies a deck's card list
+// by: the hex MD5 of the deck's card IDs (e.g. "CS2_029"), one per copy,
+// sorted and joined with commas. Heroes and format are not included.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func HSReplayDigest(deck Deck, db *CardDB) (string, error) {
+	var ids []string
+	err := tally(deck, db, func(card CardInfo, count uint64) {
+		for i := uint64(0); i < count; i++ {
+			ids = append(ids, card.ID)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, id := range ids {
+		if id == "" {
+			return "", fmt.Errorf("card without a card ID in deck")
+		}
+	}
+
+	sort.Strings(ids)
+	sum := md5.Sum([]byte(strings.Join(ids, ",")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HSReplayShortID returns the ID HSReplay.net uses for a deck in its API and
+// deck page URLs, e.g. https://hsreplay.net/decks/<id>/, computed locally
+// rather than looked up. The ID is the HSReplayDigest read as a 128-bit UUID
+// and encoded as HSReplay.net does with shortuuid releases before 1.0: in base
+// 57, least significant digit first, padded to 22 digits.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func HSReplayShortID(deck Deck, db *CardDB) (string, error) {
+	digest, err := HSReplayDigest(deck, db)
+	if err != nil {
+		return "", err
+	}
+
+	n, _ := new(big.Int).SetString(digest, 16)
+	base := big.NewInt(int64(len(hsreplayAlphabet)))
+	digit := new(big.Int)
+
+	id := make([]byte, 0, hsreplayIDLength)
+	for n.Sign() > 0 {
+		n.DivMod(n, base, digit)
+		id = append(id, hsreplayAlphabet[digit.Int64()])
+	}
+	for len(id) < hsreplayIDLength {
+		id = append(id, hsreplayAlphabet[0])
+	}
+
+	return string(id), nil
+}
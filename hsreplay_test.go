@@ -0,0 +1,49 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHSReplayDigest(t *testing.T) {
+	digest, err := HSReplayDigest(testMageDeck(), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, "e8ce0a17bd3c236c3ff07ed2a85e3f0c", digest)
+
+	// The digest of an empty card list is the MD5 of the empty string.
+	digest, err = HSReplayDigest(Deck{Heroes: []uint64{637}}, testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", digest)
+}
+
+func TestHSReplayShortID(t *testing.T) {
+	db := testCardDB(t)
+
+	id, err := HSReplayShortID(testMageDeck(), db)
+	assert.Nil(t, err)
+	assert.Equal(t, "HkfsbKQ8EYhp9Q6W4XWzRj", id)
+
+	// Card order, duplicate entries, and heroes don't matter.
+	deck := testMageDeck()
+	deck.Heroes = []uint64{7}
+	deck.Cards = append(deck.Cards[1:], [2]uint64{77, 1}, [2]uint64{77, 1})
+	other, err := HSReplayShortID(deck, db)
+	assert.Nil(t, err)
+	assert.Equal(t, id, other)
+}
+
+// The expected IDs were computed independently of this package, in Python,
+// following the pre-1.0 shortuuid encoding of UUID(digest).int.
+func TestHSReplayShortIDEncoding(t *testing.T) {
+	id, err := HSReplayShortID(Deck{}, testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, "bKHdZ8W98Amrwp75effBkf", id)
+}
+
+func TestHSReplayShortIDUnknownCard(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{999999, 1}}}
+	_, err := HSReplayShortID(deck, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
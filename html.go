@@ -0,0 +1,120 @@
+package deckstrings
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// CostGroup is the cards in a deck sharing a mana cost.
+type CostGroup struct {
+	Cost  int
+	Cards []DeckCard
+}
+
+// GroupByCost groups cards by mana cost in ascending order, keeping the order
+// of cards within each group. Cards from Enrich are grouped in game order.
+func GroupByCost(cards []DeckCard) []CostGroup {
+	var groups []CostGroup
+	index := make(map[int]int)
+	for _, card := range cards {
+		i, ok := index[card.Cost]
+		if !ok {
+			i = len(groups)
+			index[card.Cost] = i
+			groups = append(groups, CostGroup{Cost: card.Cost})
+		}
+		groups[i].Cards = append(groups[i].Cards, card)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Cost < groups[j].Cost })
+	return groups
+}
+
+// TemplateFuncs returns functions for use in html/template templates rendering
+// decks with card metadata from db:
+//
+//	cards       Deck -> []DeckCard, the deck's cards in game order (see Enrich)
+//	costGroups  Deck -> []CostGroup, the deck's cards grouped by cost
+//	card        uint64 -> CardInfo, a card's metadata, empty if unknown
+//	className   Deck -> string, the display name of the deck's class, e.g. "Mage"
+//	deckstring  Deck -> string, the deck's deckstring
+//	rarityClass Rarity -> string, a CSS class for a rarity, e.g. "rarity-legendary"
+//
+// The cards and costGroups functions fail the template if a card is not in db.
+func TemplateFuncs(db *CardDB) template.FuncMap {
+	return template.FuncMap{
+		"cards": func(deck Deck) ([]DeckCard, error) {
+			return Enrich(deck, db)
+		},
+		"costGroups": func(deck Deck) ([]CostGroup, error) {
+			cards, err := Enrich(deck, db)
+			if err != nil {
+				return nil, err
+			}
+			return GroupByCost(cards), nil
+		},
+		"card": func(dbfID uint64) CardInfo {
+			card, _ := db.Card(dbfID)
+			return card
+		},
+		"className": func(deck Deck) string {
+			return ClassName(deck, db)
+		},
+		"deckstring": func(deck Deck) (string, error) {
+			return Encode(deck)
+		},
+		"rarityClass": func(rarity Rarity) string {
+			return "rarity-" + strings.ToLower(string(rarity))
+		},
+	}
+}
+
+// deckHTML is the default HTML fragment for a deck. Cards carry their DBF ID,
+// count, and cost in data attributes for scripting.
+const deckHTML = `<div class="deck" data-deckstring="{{deckstring .}}" data-format="{{printf "%d" .Format}}">
+{{- with className .}}
+  <h2 class="deck-class">{{.}}</h2>
+{{- end}}
+{{- range costGroups .}}
+  <section class="deck-cost" data-cost="{{.Cost}}">
+    <ul>
+{{- range .Cards}}
+      <li class="deck-card {{rarityClass .Rarity}}" data-dbf-id="{{.DBFID}}" data-count="{{.Count}}" data-cost="{{.Cost}}"><span class="deck-card-cost">{{.Cost}}</span> <span class="deck-card-name">{{.Name}}</span>{{if gt .Count 1}} <span class="deck-card-count">×{{.Count}}</span>{{end}}</li>
+{{- end}}
+    </ul>
+  </section>
+{{- end}}
+</div>
+`
+
+// RenderHTML renders a deck as an HTML fragment: a div carrying the deck's
+// deckstring and format in data attributes, with a section per mana cost
+// listing the deck's cards in game order. Each card is an li element with
+// data-dbf-id, data-count, and data-cost attributes and a rarity CSS class,
+// e.g. "rarity-legendary". Sites wanting different markup can write their own
+// template using TemplateFuncs.
+//
+// Returns an UnknownCardError if a card in the deck is not in db, or an error
+// if the deck cannot be encoded.
+func RenderHTML(deck Deck, db *CardDB) (template.HTML, error) {
+	// Check the deck up front so errors aren't wrapped by the template.
+	if _, err := Enrich(deck, db); err != nil {
+		return "", err
+	}
+	if _, err := Encode(deck); err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("deck").Funcs(TemplateFuncs(db)).Parse(deckHTML)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, deck); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
@@ -0,0 +1,58 @@
+package deckstrings_test
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupByCost(t *testing.T) {
+	cards, err := Enrich(testMageDeck(), testCardDB(t))
+	require.Nil(t, err)
+
+	groups := GroupByCost(cards)
+	costs := make([]int, len(groups))
+	for i, group := range groups {
+		costs[i] = group.Cost
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 7, 9}, costs)
+	assert.Len(t, groups[3].Cards, 4)
+	assert.Equal(t, "Fireball", groups[3].Cards[0].Name)
+}
+
+func TestRenderHTML(t *testing.T) {
+	deck := Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{581, 1}, {315, 2}}}
+
+	html, err := RenderHTML(deck, testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, template.HTML(`<div class="deck" data-deckstring="`+MustEncode(deck)+`" data-format="2">
+  <h2 class="deck-class">Mage</h2>
+  <section class="deck-cost" data-cost="4">
+    <ul>
+      <li class="deck-card rarity-free" data-dbf-id="315" data-count="2" data-cost="4"><span class="deck-card-cost">4</span> <span class="deck-card-name">Fireball</span> <span class="deck-card-count">×2</span></li>
+    </ul>
+  </section>
+  <section class="deck-cost" data-cost="9">
+    <ul>
+      <li class="deck-card rarity-legendary" data-dbf-id="581" data-count="1" data-cost="9"><span class="deck-card-cost">9</span> <span class="deck-card-name">Alexstrasza</span></li>
+    </ul>
+  </section>
+</div>
+`), html)
+
+	_, err = RenderHTML(Deck{Cards: [][2]uint64{{999999, 1}}}, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(TemplateFuncs(testCardDB(t))).Parse(
+		`{{(card 581).Name}}: {{range cards .}}{{.Count}}x {{.Name}}; {{end}}{{className .}}`))
+
+	var buf bytes.Buffer
+	require.Nil(t, tmpl.Execute(&buf, Deck{Heroes: []uint64{7}, Cards: [][2]uint64{{401, 2}, {315, 1}}}))
+	assert.Equal(t, "Alexstrasza: 2x Fiery War Axe; 1x Fireball; Warrior", buf.String())
+}
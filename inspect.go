@@ -0,0 +1,123 @@
+package deckstrings
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GroupSpan describes where a card group is located in a deckstring payload.
+// Offset and Length are in bytes and cover the group's length prefix along
+// with all of its cards and counts. Cards is the number of cards in the group.
+type GroupSpan struct {
+	Group  int
+	Offset int
+	Length int
+	Cards  int
+}
+
+// Inspection is a byte-level trace of decoding a deckstring.
+//
+// Payload holds the base64-decoded bytes, including any bytes following the
+// last card group. Fields lists every varint read, in wire order, and Groups
+// marks the boundaries of each card group.
+type Inspection struct {
+	Payload []byte
+	Fields  []Field
+	Groups  []GroupSpan
+}
+
+// Inspect decodes a deckstring and returns a trace of every value read from it.
+//
+// Inspect is meant for debugging malformed or hand-crafted deckstrings. When
+// decoding fails, the returned Inspection describes everything read up to the
+// point of failure along with the error. Payloads longer than
+// DefaultMaxPayloadLength are rejected.
+func Inspect(deckstring string) (inspection Inspection, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring inspect")
+		}
+	}()
+
+	limited := &limitedReader{reader: newPayloadReader(deckstring), limit: DefaultMaxPayloadLength}
+	reader := &recordingReader{reader: limited}
+
+	err = parse(reader, func(field Field) error {
+		inspection.Fields = append(inspection.Fields, field)
+
+		switch {
+		case field.Kind == FieldGroupLength:
+			inspection.Groups = append(inspection.Groups, GroupSpan{
+				Group:  field.Group,
+				Offset: field.Offset,
+				Length: field.Length,
+				Cards:  int(field.Value),
+			})
+		case field.Group > 0:
+			span := &inspection.Groups[len(inspection.Groups)-1]
+			span.Length = field.Offset + field.Length - span.Offset
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		// Capture any trailing bytes so they show up in the payload.
+		readRemaining(reader)
+		if limited.exceeded {
+			err = limited.err()
+		}
+	}
+
+	inspection.Payload = reader.bytes
+	return inspection, err
+}
+
+// String renders the inspection as a table with one row per field showing its
+// offset, raw bytes, interpretation, and value.
+func (i Inspection) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%-6s  %-20s  %-12s  %s\n", "offset", "bytes", "field", "value")
+	end := 0
+	for _, field := range i.Fields {
+		raw := make([]string, field.Length)
+		for j := range raw {
+			raw[j] = fmt.Sprintf("%02x", i.Payload[field.Offset+j])
+		}
+
+		value := fmt.Sprint(field.Value)
+		if field.Kind == FieldFormat {
+			value = fmt.Sprintf("%d (%s)", field.Value, Format(field.Value))
+		}
+		if field.Group > 0 {
+			value = fmt.Sprintf("%s [group %d]", value, field.Group)
+		}
+
+		fmt.Fprintf(&b, "%6d  %-20s  %-12s  %s\n", field.Offset, strings.Join(raw, " "), field.Kind, value)
+		end = field.Offset + field.Length
+	}
+
+	if end < len(i.Payload) {
+		fmt.Fprintf(&b, "%6d  % x  (trailing)\n", end, i.Payload[end:])
+	}
+
+	return b.String()
+}
+
+// recordingReader keeps a copy of every byte read through it.
+type recordingReader struct {
+	reader io.ByteReader
+	bytes  []byte
+}
+
+func (r *recordingReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.bytes = append(r.bytes, b)
+	}
+	return b, err
+}
@@ -0,0 +1,81 @@
+package deckstrings_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	inspection, err := Inspect("AAEBAgIBAQEAAQcC")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []byte{0, 1, 1, 2, 2, 1, 1, 1, 0, 1, 7, 2}, inspection.Payload)
+	assert.Equal(t, []Field{
+		{Kind: FieldReserved, Offset: 0, Length: 1, Value: 0},
+		{Kind: FieldVersion, Offset: 1, Length: 1, Value: 1},
+		{Kind: FieldFormat, Offset: 2, Length: 1, Value: 1},
+		{Kind: FieldHeroCount, Offset: 3, Length: 1, Value: 2},
+		{Kind: FieldHero, Offset: 4, Length: 1, Value: 2},
+		{Kind: FieldHero, Offset: 5, Length: 1, Value: 1},
+		{Kind: FieldGroupLength, Offset: 6, Length: 1, Value: 1, Group: 1},
+		{Kind: FieldCard, Offset: 7, Length: 1, Value: 1, Group: 1},
+		{Kind: FieldGroupLength, Offset: 8, Length: 1, Value: 0, Group: 2},
+		{Kind: FieldGroupLength, Offset: 9, Length: 1, Value: 1, Group: 3},
+		{Kind: FieldCard, Offset: 10, Length: 1, Value: 7, Group: 3},
+		{Kind: FieldCount, Offset: 11, Length: 1, Value: 2, Group: 3},
+	}, inspection.Fields)
+}
+
+func TestInspectMultibyte(t *testing.T) {
+	inspection, err := Inspect("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.Nil(t, err)
+
+	hero := inspection.Fields[4]
+	assert.Equal(t, Field{Kind: FieldHero, Offset: 4, Length: 1, Value: 31}, hero)
+
+	card := inspection.Fields[6]
+	assert.Equal(t, Field{Kind: FieldCard, Offset: 6, Length: 2, Value: 455, Group: 1}, card)
+
+	assert.Equal(t, []GroupSpan{
+		{Group: 1, Offset: 5, Length: 13, Cards: 6},
+		{Group: 2, Offset: 18, Length: 25, Cards: 12},
+		{Group: 3, Offset: 43, Length: 1, Cards: 0},
+	}, inspection.Groups)
+}
+
+func TestInspectTruncated(t *testing.T) {
+	inspection, err := Inspect("AAEB0")
+	assert.NotNil(t, err)
+	assert.Len(t, inspection.Fields, 3)
+	assert.Equal(t, FieldFormat, inspection.Fields[2].Kind)
+}
+
+func TestInspectInvalidVersion(t *testing.T) {
+	inspection, err := Inspect("AABB")
+	assert.NotNil(t, err)
+	assert.Equal(t, []Field{
+		{Kind: FieldReserved, Offset: 0, Length: 1, Value: 0},
+		{Kind: FieldVersion, Offset: 1, Length: 1, Value: 0},
+	}, inspection.Fields)
+}
+
+func ExampleInspect() {
+	inspection, err := Inspect("AAEBAR8BxwMAAA==")
+	fmt.Print(inspection)
+	fmt.Println(err)
+	// Output:
+	// offset  bytes                 field         value
+	//      0  00                    reserved      0
+	//      1  01                    version       1
+	//      2  01                    format        1 (Wild)
+	//      3  01                    hero count    1
+	//      4  1f                    hero          31
+	//      5  01                    group length  1 [group 1]
+	//      6  c7 03                 card          455 [group 1]
+	//      8  00                    group length  0 [group 2]
+	//      9  00                    group length  0 [group 3]
+	// <nil>
+}
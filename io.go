@@ -0,0 +1,38 @@
+package deckstrings
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// WriteTo implements io.WriterTo, writing the deck's canonical deckstring to
+// writer.
+func (d Deck) WriteTo(writer io.Writer) (int64, error) {
+	n, err := EncodeTo(writer, d)
+	return int64(n), err
+}
+
+// ReadFrom implements io.ReaderFrom, reading a deckstring from reader until
+// EOF and decoding it into the deck. Surrounding whitespace, such as a
+// trailing newline, is ignored. Input longer than a megabyte is rejected.
+func (d *Deck) ReadFrom(reader io.Reader) (int64, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxLineLength+1))
+	n := int64(len(data))
+	if err != nil {
+		return n, errors.Wrap(err, "deckstring read")
+	}
+
+	if len(data) > maxLineLength {
+		return n, errors.Errorf("deckstring read: input exceeds %d bytes", maxLineLength)
+	}
+
+	deck, err := DecodeBytes(bytes.TrimSpace(data))
+	if err != nil {
+		return n, err
+	}
+
+	*d = deck
+	return n, nil
+}
@@ -0,0 +1,54 @@
+package deckstrings_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeckWriteTo(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck := MustDecode(deckstring)
+
+	var buf bytes.Buffer
+	n, err := deck.WriteTo(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(deckstring)), n)
+	assert.Equal(t, deckstring, buf.String())
+}
+
+func TestDeckWriteToInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := Deck{Cards: [][2]uint64{{1, 0}}}.WriteTo(&buf)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestDeckReadFrom(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	var deck Deck
+	n, err := deck.ReadFrom(strings.NewReader(deckstring + "\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(deckstring)+1), n)
+	assert.Equal(t, MustDecode(deckstring), deck)
+}
+
+func TestDeckReadFromInvalid(t *testing.T) {
+	deck := Deck{Format: FormatWild}
+	_, err := deck.ReadFrom(strings.NewReader("AAIAAAAAAA=="))
+	assert.NotNil(t, err)
+	assert.Equal(t, Deck{Format: FormatWild}, deck)
+
+	_, err = deck.ReadFrom(strings.NewReader(strings.Repeat("A", 2*1024*1024)))
+	assert.NotNil(t, err)
+}
+
+var (
+	_ io.WriterTo   = Deck{}
+	_ io.ReaderFrom = &Deck{}
+)
@@ -0,0 +1,66 @@
+package deckstrings
+
+import (
+	"iter"
+
+	"github.com/pkg/errors"
+)
+
+// DecodeCards returns an iterator over the (DBF ID, count) pairs of a
+// deckstring's cards, decoded lazily without building a card list. This suits
+// streaming analytics over large corpora.
+//
+// Cards are yielded in wire order, which is not necessarily ordered by DBF ID,
+// and duplicate entries are not merged. Decoding errors end the iteration and
+// are reported by the returned error function, which should be checked once
+// iteration completes:
+//
+//	cards, errf := deckstrings.DecodeCards(deckstring)
+//	for dbfID, count := range cards {
+//		...
+//	}
+//	if err := errf(); err != nil {
+//		...
+//	}
+//
+// Deckstrings and options are handled as in ParseHeader. The cards of a
+// deckstring decoded by a registered codec are yielded in the order the codec
+// returns them.
+func DecodeCards(deckstring string, opts ...DecodeOption) (iter.Seq2[uint64, uint64], func() error) {
+	options := newDecodeOptions(opts)
+	var err error
+
+	seq := func(yield func(uint64, uint64) bool) {
+		err = nil
+
+		var dbfID uint64
+		deck, parseErr := parseDeckstring(deckstring, options, func(field Field) error {
+			switch {
+			case field.Kind == FieldCard:
+				dbfID = field.Value
+				if field.Group < 3 && !yield(dbfID, uint64(field.Group)) {
+					return errStop
+				}
+			case field.Kind == FieldCount:
+				if !yield(dbfID, field.Value) {
+					return errStop
+				}
+			}
+			return nil
+		})
+
+		if deck != nil {
+			for _, card := range deck.Cards {
+				if !yield(card[0], card[1]) {
+					return
+				}
+			}
+		}
+
+		if parseErr != nil && parseErr != errStop {
+			err = errors.Wrap(parseErr, "deckstring decode cards")
+		}
+	}
+
+	return seq, func() error { return err }
+}
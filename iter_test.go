@@ -0,0 +1,77 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCards(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck, err := Decode(deckstring)
+	assert.Nil(t, err)
+
+	cards, errf := DecodeCards(deckstring)
+	counts := make(map[uint64]uint64)
+	for dbfID, count := range cards {
+		counts[dbfID] += count
+	}
+	assert.Nil(t, errf())
+	assert.Equal(t, deck.CardsMap(), counts)
+}
+
+func TestDecodeCardsWireOrder(t *testing.T) {
+	cards, errf := DecodeCards("AAEAAAMDAgEAAQEB")
+
+	var pairs [][2]uint64
+	for dbfID, count := range cards {
+		pairs = append(pairs, [2]uint64{dbfID, count})
+	}
+	assert.Nil(t, errf())
+	assert.Equal(t, [][2]uint64{{3, 1}, {2, 1}, {1, 1}, {1, 1}}, pairs)
+}
+
+func TestDecodeCardsBreak(t *testing.T) {
+	cards, errf := DecodeCards("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+
+	n := 0
+	for range cards {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, n)
+	assert.Nil(t, errf())
+}
+
+func TestDecodeCardsDecodeOptions(t *testing.T) {
+	deck := researchDeck()
+	for _, deckstring := range []string{MustEncode(deck, Compress()), MustEncode(deck, EncodeVersion(99))} {
+		cards, errf := DecodeCards(deckstring)
+		counts := make(map[uint64]uint64)
+		for dbfID, count := range cards {
+			counts[dbfID] += count
+		}
+		assert.Nil(t, errf())
+		assert.Equal(t, deck.CardsMap(), counts)
+	}
+
+	cards, errf := DecodeCards(MustEncode(deck), MaxPayloadLength(100))
+	for range cards {
+	}
+	assert.NotNil(t, errf())
+}
+
+func TestDecodeCardsInvalid(t *testing.T) {
+	// Truncated after the first card of the 1x group.
+	cards, errf := DecodeCards("AAEBAQcBBQ==")
+
+	var pairs [][2]uint64
+	for dbfID, count := range cards {
+		pairs = append(pairs, [2]uint64{dbfID, count})
+	}
+	assert.Equal(t, [][2]uint64{{5, 1}}, pairs)
+	assert.NotNil(t, errf())
+}
@@ -0,0 +1,104 @@
+package deckstrings
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// The deck list container version supported by this package.
+const ListVersion = 1
+
+// EncodeList packs multiple decks into a single base64 string, such as a
+// tournament lineup or a bundle of decks to share as one code. Use DecodeList
+// to recover the decks. Options apply to each deck as in Encode.
+//
+// A deck list is a base64-encoded container:
+//
+//	magic       0xFF 'L'
+//	version     ListVersion
+//	deck count  followed by each deck
+//	deck        payload length followed by the deck's deckstring payload
+//
+// Deck lists are not deckstrings and can't be imported into the game.
+func EncodeList(decks []Deck, opts ...EncodeOption) (list string, err error) {
+	options := newEncodeOptions(opts)
+
+	var buf bytes.Buffer
+	writeContainerHeader(&buf, containerList, ListVersion)
+	(&varintWriter{&buf}).Write(uint64(len(decks)))
+
+	var payload bytes.Buffer
+	for i, deck := range decks {
+		payload.Reset()
+		if err := encodePayload(&payload, deck, options); err != nil {
+			return "", errors.Wrapf(err, "deck list encode: deck %d", i)
+		}
+		writeBytes(&buf, payload.Bytes())
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeList decodes a deck list created by EncodeList. A plain deckstring is
+// also accepted and decodes to a list of one deck. Options apply to each deck
+// as in Decode.
+func DecodeList(list string, opts ...DecodeOption) (decks []Deck, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deck list decode")
+		}
+	}()
+
+	options := newDecodeOptions(opts)
+
+	payload, err := base64.StdEncoding.DecodeString(list)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isContainer(payload, containerList) {
+		deck, _, err := decode(bytes.NewReader(payload), options)
+		if err != nil {
+			return nil, err
+		}
+		return []Deck{deck}, nil
+	}
+
+	reader, err := readContainerHeader(payload, containerList, ListVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := (&varintReader{reader: reader}).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each deck takes at least one byte, which bounds the allocation below.
+	if count > uint64(reader.Len()) {
+		return nil, fmt.Errorf("deck count %d exceeds remaining %d bytes", count, reader.Len())
+	}
+
+	decks = make([]Deck, 0, count)
+	for i := uint64(0); i < count; i++ {
+		deckPayload, err := readBytes(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "deck %d", i)
+		}
+
+		deck, _, err := decode(bytes.NewReader(deckPayload), options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "deck %d", i)
+		}
+		decks = append(decks, deck)
+	}
+
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("unexpected trailing data: %d bytes", reader.Len())
+	}
+
+	return decks, nil
+}
@@ -0,0 +1,67 @@
+package deckstrings_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeList(t *testing.T) {
+	decks := []Deck{
+		MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="),
+		MustDecode("AAECAZICCPIF+Az5DK6rAuC7ApS9AsnHApnTAgtAX/4BxAbkCLS7Asu8As+8At2+AqDNAofOAgA="),
+		MustDecode("AAEAAAAAAA=="),
+	}
+
+	list, err := EncodeList(decks)
+	assert.Nil(t, err)
+
+	decoded, err := DecodeList(list)
+	assert.Nil(t, err)
+	assert.Equal(t, decks, decoded)
+
+	// Lists are not deckstrings.
+	_, err = Decode(list)
+	assert.NotNil(t, err)
+}
+
+func TestEncodeListEmpty(t *testing.T) {
+	list, err := EncodeList(nil)
+	assert.Nil(t, err)
+
+	decoded, err := DecodeList(list)
+	assert.Nil(t, err)
+	assert.Equal(t, []Deck{}, decoded)
+}
+
+func TestEncodeListInvalidDeck(t *testing.T) {
+	_, err := EncodeList([]Deck{{}, {Cards: [][2]uint64{{1, 0}}}})
+	assert.EqualError(t, err, "deck list encode: deck 1: invalid card count for DBF ID 1")
+}
+
+func TestDecodeListDeckstring(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	decks, err := DecodeList(deckstring)
+	assert.Nil(t, err)
+	assert.Equal(t, []Deck{MustDecode(deckstring)}, decks)
+}
+
+func TestDecodeListInvalid(t *testing.T) {
+	invalid := map[string][]byte{
+		"unsupported container version: 2":           {0xFF, 'L', 2, 0},
+		"deck count 100 exceeds remaining 0 bytes":   {0xFF, 'L', 1, 100},
+		"deck 0: length 9 exceeds remaining 0 bytes": {0xFF, 'L', 1, 1, 9},
+		"deck 0: unsupported version: 2":             {0xFF, 'L', 1, 1, 2, 0, 2},
+		"unexpected trailing data: 1 bytes":          {0xFF, 'L', 1, 0, 0},
+	}
+
+	for message, payload := range invalid {
+		_, err := DecodeList(base64.StdEncoding.EncodeToString(payload))
+		assert.EqualError(t, err, "deck list decode: "+message)
+	}
+
+	_, err := DecodeList("!")
+	assert.NotNil(t, err)
+}
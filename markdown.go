@@ -0,0 +1,106 @@
+package deckstrings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarkdownOption configures how RenderMarkdown renders a deck.
+type MarkdownOption func(*markdownOptions)
+
+type markdownOptions struct {
+	title       string
+	list        bool
+	rarityEmoji bool
+}
+
+// MarkdownTitle renders title as a heading above the deck.
+func MarkdownTitle(title string) MarkdownOption {
+	return func(o *markdownOptions) {
+		o.title = title
+	}
+}
+
+// MarkdownList renders cards as a bulleted list, e.g. "- 2x (4) Fireball",
+// rather than a table, for sites that don't render Markdown tables.
+func MarkdownList() MarkdownOption {
+	return func(o *markdownOptions) {
+		o.list = true
+	}
+}
+
+// MarkdownRarityEmoji marks each card with an emoji for its rarity, e.g. 🟠
+// for legendary cards.
+func MarkdownRarityEmoji() MarkdownOption {
+	return func(o *markdownOptions) {
+		o.rarityEmoji = true
+	}
+}
+
+var rarityEmoji = map[Rarity]string{
+	RarityCommon:    "⚪",
+	RarityRare:      "🔵",
+	RarityEpic:      "🟣",
+	RarityLegendary: "🟠",
+}
+
+// RenderMarkdown renders a deck as Markdown ready to paste into Reddit,
+// GitHub, or Discord: the deck's class and format, a table of cards with their
+// cost, name, and count, and the deckstring in a fenced code block. Cards are
+// ordered by cost, then name.
+//
+// Returns an UnknownCardError if a card in the deck is not in db, or an error
+// if the deck cannot be encoded.
+func RenderMarkdown(deck Deck, db *CardDB, opts ...MarkdownOption) (string, error) {
+	options := &markdownOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	deckstring, err := Encode(deck)
+	if err != nil {
+		return "", err
+	}
+
+	cards, err := Enrich(deck, db)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if options.title != "" {
+		fmt.Fprintf(&b, "### %s\n\n", escapeMarkdown(options.title))
+	}
+	if class := ClassName(deck, db); class != "" {
+		fmt.Fprintf(&b, "**Class:** %s  \n", class)
+	}
+	fmt.Fprintf(&b, "**Format:** %s\n\n", deck.Format)
+
+	if !options.list {
+		b.WriteString("| Cost | Name | Count |\n")
+		b.WriteString("|-----:|------|------:|\n")
+	}
+	for _, card := range cards {
+		name := escapeMarkdown(card.Name)
+		if emoji, ok := rarityEmoji[card.Rarity]; ok && options.rarityEmoji {
+			name = emoji + " " + name
+		}
+		if options.list {
+			fmt.Fprintf(&b, "- %dx (%d) %s\n", card.Count, card.Cost, name)
+		} else {
+			fmt.Fprintf(&b, "| %d | %s | %d |\n", card.Cost, name, card.Count)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n```\n%s\n```\n", deckstring)
+	return b.String(), nil
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `|`, `\|`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`,
+)
+
+// escapeMarkdown escapes characters in s that Markdown would interpret.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,44 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	deck := Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{581, 1}, {315, 2}, {77, 2}}}
+
+	markdown, err := RenderMarkdown(deck, testCardDB(t), MarkdownTitle("Big *Mage*"))
+	assert.Nil(t, err)
+	assert.Equal(t, "### Big \\*Mage\\*\n\n"+
+		"**Class:** Mage  \n"+
+		"**Format:** Standard\n\n"+
+		"| Cost | Name | Count |\n"+
+		"|-----:|------|------:|\n"+
+		"| 4 | Fireball | 2 |\n"+
+		"| 4 | Polymorph | 2 |\n"+
+		"| 9 | Alexstrasza | 1 |\n"+
+		"\n```\n"+MustEncode(deck)+"\n```\n", markdown)
+}
+
+func TestRenderMarkdownList(t *testing.T) {
+	deck := Deck{Format: FormatWild, Heroes: []uint64{7}, Cards: [][2]uint64{{401, 2}, {581, 1}}}
+
+	markdown, err := RenderMarkdown(deck, testCardDB(t), MarkdownList(), MarkdownRarityEmoji())
+	assert.Nil(t, err)
+	assert.Equal(t, "**Class:** Warrior  \n"+
+		"**Format:** Wild\n\n"+
+		"- 2x (3) Fiery War Axe\n"+
+		"- 1x (9) 🟠 Alexstrasza\n"+
+		"\n```\n"+MustEncode(deck)+"\n```\n", markdown)
+}
+
+func TestRenderMarkdownInvalid(t *testing.T) {
+	_, err := RenderMarkdown(Deck{Cards: [][2]uint64{{999999, 1}}}, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+
+	_, err = RenderMarkdown(Deck{Cards: [][2]uint64{{315, 0}}}, testCardDB(t))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,103 @@
+package deckstrings
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MergePolicy determines how Merge resolves decks with different formats or heroes.
+type MergePolicy int
+
+const (
+	// MergeStrict fails if the decks do not all share the same format and heroes.
+	MergeStrict MergePolicy = iota
+
+	// MergeFirst uses the format and heroes of the first deck.
+	MergeFirst
+
+	// MergeUnion combines the heroes of every deck. If formats differ, the
+	// merged deck has the zero Format.
+	MergeUnion
+)
+
+// Merge combines decks into a single deck by summing card counts, using the
+// MergeStrict policy. See MergeWith.
+func Merge(decks ...Deck) (Deck, error) {
+	return MergeWith(MergeStrict, decks...)
+}
+
+// MergeWith combines decks into a single deck by summing card counts,
+// resolving differing formats and heroes according to policy. This is useful
+// for building aggregate card pools from multiple deckstrings.
+//
+// The merged deck's heroes and cards are ordered by DBF ID ascending and each
+// card's DBF ID appears once. Merging no decks results in an empty deck.
+func MergeWith(policy MergePolicy, decks ...Deck) (Deck, error) {
+	if policy < MergeStrict || policy > MergeUnion {
+		return Deck{}, fmt.Errorf("merge: invalid policy: %d", policy)
+	}
+
+	merged := Deck{Heroes: []uint64{}, Cards: [][2]uint64{}}
+	if len(decks) == 0 {
+		return merged, nil
+	}
+
+	first := decks[0]
+	merged.Format = first.Format
+	merged.Heroes = sortedHeroes(first.Heroes)
+
+	heroes := heroSet(first.Heroes)
+	counts := make(map[uint64]uint64)
+
+	for i, deck := range decks {
+		switch policy {
+		case MergeStrict:
+			if deck.Format != first.Format {
+				return Deck{}, fmt.Errorf("merge: deck %d format %s differs from %s", i, deck.Format, first.Format)
+			}
+			if !equalHeroes(sortedHeroes(deck.Heroes), merged.Heroes) {
+				return Deck{}, fmt.Errorf("merge: deck %d heroes %v differ from %v", i, deck.Heroes, first.Heroes)
+			}
+		case MergeUnion:
+			if deck.Format != merged.Format {
+				merged.Format = Format(0)
+			}
+			for _, hero := range deck.Heroes {
+				heroes[hero] = 1
+			}
+		}
+
+		for _, card := range deck.Cards {
+			counts[card[0]] += card[1]
+		}
+	}
+
+	if policy == MergeUnion {
+		merged.Heroes = sortedKeys(heroes)
+	}
+
+	for _, dbfID := range sortedKeys(counts) {
+		merged.Cards = append(merged.Cards, [2]uint64{dbfID, counts[dbfID]})
+	}
+
+	return merged, nil
+}
+
+func sortedHeroes(heroes []uint64) []uint64 {
+	sorted := make([]uint64, len(heroes))
+	copy(sorted, heroes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func equalHeroes(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	a := Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{315, 2}, {77, 1}}}
+	b := Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{77, 2}, {662, 2}}}
+
+	merged, err := Merge(a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{
+		Format: FormatStandard,
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{77, 3}, {315, 2}, {662, 2}},
+	}, merged)
+}
+
+func TestMergeEmpty(t *testing.T) {
+	merged, err := Merge()
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{Heroes: []uint64{}, Cards: [][2]uint64{}}, merged)
+}
+
+func TestMergeStrictConflicts(t *testing.T) {
+	a := Deck{Format: FormatStandard, Heroes: []uint64{637}}
+
+	_, err := Merge(a, Deck{Format: FormatWild, Heroes: []uint64{637}})
+	assert.NotNil(t, err)
+
+	_, err = Merge(a, Deck{Format: FormatStandard, Heroes: []uint64{7}})
+	assert.NotNil(t, err)
+}
+
+func TestMergeFirst(t *testing.T) {
+	a := Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{1, 1}}}
+	b := Deck{Format: FormatWild, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 1}}}
+
+	merged, err := MergeWith(MergeFirst, a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{1, 2}}}, merged)
+}
+
+func TestMergeUnion(t *testing.T) {
+	a := Deck{Format: FormatStandard, Heroes: []uint64{637}, Cards: [][2]uint64{{1, 1}}}
+	b := Deck{Format: FormatWild, Heroes: []uint64{7, 637}, Cards: [][2]uint64{{2, 1}}}
+
+	merged, err := MergeWith(MergeUnion, a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, Deck{Format: Format(0), Heroes: []uint64{7, 637}, Cards: [][2]uint64{{1, 1}, {2, 1}}}, merged)
+
+	merged, err = MergeWith(MergeUnion, a, a)
+	assert.Nil(t, err)
+	assert.Equal(t, FormatStandard, merged.Format)
+}
+
+func TestMergeInvalidPolicy(t *testing.T) {
+	_, err := MergeWith(MergePolicy(42), Deck{})
+	assert.NotNil(t, err)
+}
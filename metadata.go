@@ -0,0 +1,168 @@
+package deckstrings
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// The metadata container version supported by this package.
+const MetadataVersion = 1
+
+// Metadata is descriptive information about a deck that deckstrings can't
+// carry, such as its name and author. All strings must be valid UTF-8.
+type Metadata struct {
+	Name   string
+	Author string
+	Tags   []string
+}
+
+// EncodeWithMetadata encodes a deck like Encode and wraps it together with
+// metadata in a base64 string. Use DecodeWithMetadata to recover both, or
+// StripMetadata to recover a deckstring that can be imported into the game.
+//
+// The metadata container is laid out as:
+//
+//	magic     0xFF 'M'
+//	version   MetadataVersion
+//	name      length followed by UTF-8 bytes
+//	author    length followed by UTF-8 bytes
+//	tags      tag count followed by each tag's length and UTF-8 bytes
+//	deck      payload length followed by the deck's deckstring payload
+func EncodeWithMetadata(deck Deck, metadata Metadata, opts ...EncodeOption) (encoded string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deck metadata encode")
+		}
+	}()
+
+	values := append([]string{metadata.Name, metadata.Author}, metadata.Tags...)
+	for _, s := range values {
+		if !utf8.ValidString(s) {
+			return "", fmt.Errorf("invalid UTF-8 string: %q", s)
+		}
+	}
+
+	var payload bytes.Buffer
+	if err := encodePayload(&payload, deck, newEncodeOptions(opts)); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	writeContainerHeader(&buf, containerMetadata, MetadataVersion)
+	writeBytes(&buf, []byte(metadata.Name))
+	writeBytes(&buf, []byte(metadata.Author))
+	(&varintWriter{&buf}).Write(uint64(len(metadata.Tags)))
+	for _, tag := range metadata.Tags {
+		writeBytes(&buf, []byte(tag))
+	}
+	writeBytes(&buf, payload.Bytes())
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeWithMetadata decodes a deck and its metadata created by
+// EncodeWithMetadata. A plain deckstring is also accepted and decodes with
+// empty metadata. Options apply to the deck as in Decode.
+func DecodeWithMetadata(encoded string, opts ...DecodeOption) (deck Deck, metadata Metadata, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deck metadata decode")
+		}
+	}()
+
+	payload, metadata, err := splitMetadata(encoded)
+	if err != nil {
+		return Deck{}, Metadata{}, err
+	}
+
+	deck, _, err = decode(bytes.NewReader(payload), newDecodeOptions(opts))
+	if err != nil {
+		return Deck{}, Metadata{}, err
+	}
+
+	return deck, metadata, nil
+}
+
+// StripMetadata removes the metadata created by EncodeWithMetadata, returning
+// the wrapped deckstring unchanged so it can be imported into the game. A plain
+// deckstring is returned as is. The deck is validated as in Decode.
+func StripMetadata(encoded string) (deckstring string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deck metadata strip")
+		}
+	}()
+
+	payload, _, err := splitMetadata(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	if _, _, err := decode(bytes.NewReader(payload), newDecodeOptions(nil)); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// splitMetadata separates a metadata container into the wrapped deckstring
+// payload and the metadata. Plain deckstrings have empty metadata.
+func splitMetadata(encoded string) ([]byte, Metadata, error) {
+	payload, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	if !isContainer(payload, containerMetadata) {
+		return payload, Metadata{}, nil
+	}
+
+	reader, err := readContainerHeader(payload, containerMetadata, MetadataVersion)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	var metadata Metadata
+	if metadata.Name, err = readString(reader); err != nil {
+		return nil, Metadata{}, errors.Wrap(err, "name")
+	}
+	if metadata.Author, err = readString(reader); err != nil {
+		return nil, Metadata{}, errors.Wrap(err, "author")
+	}
+
+	count, err := (&varintReader{reader: reader}).Read()
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+
+	// Each tag takes at least one byte, which bounds the allocation below.
+	if count > uint64(reader.Len()) {
+		return nil, Metadata{}, fmt.Errorf("tag count %d exceeds remaining %d bytes", count, reader.Len())
+	}
+
+	if count > 0 {
+		metadata.Tags = make([]string, 0, count)
+	}
+	for i := uint64(0); i < count; i++ {
+		tag, err := readString(reader)
+		if err != nil {
+			return nil, Metadata{}, errors.Wrapf(err, "tag %d", i)
+		}
+		metadata.Tags = append(metadata.Tags, tag)
+	}
+
+	deckPayload, err := readBytes(reader)
+	if err != nil {
+		return nil, Metadata{}, errors.Wrap(err, "deck")
+	}
+
+	if reader.Len() > 0 {
+		return nil, Metadata{}, fmt.Errorf("unexpected trailing data: %d bytes", reader.Len())
+	}
+
+	return deckPayload, metadata, nil
+}
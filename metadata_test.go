@@ -0,0 +1,81 @@
+package deckstrings_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeWithMetadata(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck := MustDecode(deckstring)
+	metadata := Metadata{Name: "Face Hunter 🏹", Author: "Rexxar", Tags: []string{"aggro", "budget"}}
+
+	encoded, err := EncodeWithMetadata(deck, metadata)
+	assert.Nil(t, err)
+
+	decoded, decodedMetadata, err := DecodeWithMetadata(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+	assert.Equal(t, metadata, decodedMetadata)
+
+	stripped, err := StripMetadata(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, MustEncode(deck), stripped)
+
+	// Metadata containers are not deckstrings.
+	_, err = Decode(encoded)
+	assert.NotNil(t, err)
+}
+
+func TestEncodeWithEmptyMetadata(t *testing.T) {
+	deck := MustDecode("AAEAAAAAAA==")
+
+	encoded, err := EncodeWithMetadata(deck, Metadata{})
+	assert.Nil(t, err)
+
+	decoded, metadata, err := DecodeWithMetadata(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+	assert.Equal(t, Metadata{}, metadata)
+}
+
+func TestEncodeWithMetadataInvalid(t *testing.T) {
+	_, err := EncodeWithMetadata(Deck{}, Metadata{Tags: []string{"\xff"}})
+	assert.EqualError(t, err, `deck metadata encode: invalid UTF-8 string: "\xff"`)
+
+	_, err = EncodeWithMetadata(Deck{Cards: [][2]uint64{{1, 0}}}, Metadata{})
+	assert.EqualError(t, err, "deck metadata encode: invalid card count for DBF ID 1")
+}
+
+func TestDecodeWithMetadataDeckstring(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	deck, metadata, err := DecodeWithMetadata(deckstring)
+	assert.Nil(t, err)
+	assert.Equal(t, MustDecode(deckstring), deck)
+	assert.Equal(t, Metadata{}, metadata)
+
+	stripped, err := StripMetadata(deckstring)
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring, stripped)
+}
+
+func TestDecodeWithMetadataInvalid(t *testing.T) {
+	invalid := map[string][]byte{
+		"unsupported container version: 2":         {0xFF, 'M', 2},
+		"name: length 5 exceeds remaining 0 bytes": {0xFF, 'M', 1, 5},
+		"author: invalid UTF-8 string":             {0xFF, 'M', 1, 0, 1, 0xFF},
+		"tag count 9 exceeds remaining 0 bytes":    {0xFF, 'M', 1, 0, 0, 9},
+		"deck: EOF":                                {0xFF, 'M', 1, 0, 0, 0},
+		"unexpected trailing data: 1 bytes":        {0xFF, 'M', 1, 0, 0, 0, 0, 0},
+		"unsupported version: 2":                   {0xFF, 'M', 1, 0, 0, 0, 2, 0, 2},
+	}
+
+	for message, payload := range invalid {
+		_, _, err := DecodeWithMetadata(base64.StdEncoding.EncodeToString(payload))
+		assert.EqualError(t, err, "deck metadata decode: "+message)
+	}
+}
@@ -0,0 +1,81 @@
+package deckstrings
+
+import (
+	"encoding/base64"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Metrics receives measurements from this package and its subpackages so
+// services can report them to their own telemetry backends. Implementations
+// must be safe for concurrent use and should return quickly.
+type Metrics interface {
+	// ObserveDecode is called after each deckstring is decoded, with the
+	// time taken and the error, if any. See ErrorType.
+	ObserveDecode(duration time.Duration, err error)
+
+	// ObserveEncode is called after each deck is encoded.
+	ObserveEncode(duration time.Duration, err error)
+
+	// ObserveRequest is called after each network request made by a
+	// subpackage, such as "blizzard deck" or "blizzard token".
+	ObserveRequest(operation string, duration time.Duration, err error)
+}
+
+type metricsHolder struct {
+	metrics Metrics
+}
+
+var registeredMetrics atomic.Pointer[metricsHolder]
+
+// RegisterMetrics sets the Metrics that decoding and encoding report into.
+// Passing nil unregisters the current Metrics. It is safe to call
+// concurrently with decoding and encoding.
+func RegisterMetrics(metrics Metrics) {
+	if metrics == nil {
+		registeredMetrics.Store(nil)
+		return
+	}
+	registeredMetrics.Store(&metricsHolder{metrics})
+}
+
+// loadMetrics returns the registered Metrics, or nil.
+func loadMetrics() Metrics {
+	if holder := registeredMetrics.Load(); holder != nil {
+		return holder.metrics
+	}
+	return nil
+}
+
+// ErrorType classifies an error returned by this package for metrics, e.g.
+// as a label on an error counter. It returns "" for a nil error and one of
+// "base64", "truncated", "unknown_card", "checksum", "signature", or
+// "decryption" for errors of those kinds, or "invalid" for any other error.
+func ErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	cause := errors.Cause(err)
+	switch cause.(type) {
+	case base64.CorruptInputError:
+		return "base64"
+	case UnknownCardError, UnknownCardIDError:
+		return "unknown_card"
+	case ChecksumError:
+		return "checksum"
+	}
+
+	switch cause {
+	case io.EOF, io.ErrUnexpectedEOF:
+		return "truncated"
+	case ErrSignatureMismatch:
+		return "signature"
+	case ErrDecryptionFailed:
+		return "decryption"
+	}
+	return "invalid"
+}
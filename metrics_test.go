@@ -0,0 +1,89 @@
+package deckstrings_test
+
+import (
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observation struct {
+	operation string
+	duration  time.Duration
+	err       error
+}
+
+// recordingMetrics records every observation it receives.
+type recordingMetrics struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (m *recordingMetrics) observe(operation string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observations = append(m.observations, observation{operation, duration, err})
+}
+
+func (m *recordingMetrics) ObserveDecode(duration time.Duration, err error) {
+	m.observe("decode", duration, err)
+}
+
+func (m *recordingMetrics) ObserveEncode(duration time.Duration, err error) {
+	m.observe("encode", duration, err)
+}
+
+func (m *recordingMetrics) ObserveRequest(operation string, duration time.Duration, err error) {
+	m.observe(operation, duration, err)
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	RegisterMetrics(metrics)
+	t.Cleanup(func() { RegisterMetrics(nil) })
+
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck, err := Decode(deckstring)
+	require.Nil(t, err)
+	_, err = Encode(deck)
+	require.Nil(t, err)
+	_, err = Decode("AAEC")
+	require.NotNil(t, err)
+	_, err = Encode(Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{1, 0}}})
+	require.NotNil(t, err)
+
+	require.Len(t, metrics.observations, 4)
+	assert.Equal(t, "decode", metrics.observations[0].operation)
+	assert.Nil(t, metrics.observations[0].err)
+	assert.Equal(t, "encode", metrics.observations[1].operation)
+	assert.Nil(t, metrics.observations[1].err)
+	assert.Equal(t, "decode", metrics.observations[2].operation)
+	assert.Equal(t, "truncated", ErrorType(metrics.observations[2].err))
+	assert.Equal(t, "encode", metrics.observations[3].operation)
+	assert.Equal(t, "invalid", ErrorType(metrics.observations[3].err))
+
+	RegisterMetrics(nil)
+	_, err = Decode(deckstring)
+	require.Nil(t, err)
+	assert.Len(t, metrics.observations, 4)
+}
+
+func TestErrorType(t *testing.T) {
+	assert.Equal(t, "", ErrorType(nil))
+	assert.Equal(t, "base64", ErrorType(errors.Wrap(base64.CorruptInputError(3), "deckstring")))
+	assert.Equal(t, "unknown_card", ErrorType(errors.Wrap(UnknownCardError{DBFID: 1}, "deck")))
+	assert.Equal(t, "unknown_card", ErrorType(UnknownCardIDError{ID: "CS2_029"}))
+	assert.Equal(t, "signature", ErrorType(ErrSignatureMismatch))
+	assert.Equal(t, "decryption", ErrorType(ErrDecryptionFailed))
+	assert.Equal(t, "invalid", ErrorType(errors.New("invalid")))
+
+	_, err := Decode("AA!!AAAA")
+	assert.Equal(t, "base64", ErrorType(err))
+	_, err = DecodeChecksum("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAE=.B/RSpg")
+	assert.Equal(t, "checksum", ErrorType(err))
+}
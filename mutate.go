@@ -0,0 +1,61 @@
+package deckstrings
+
+import "fmt"
+
+// AddCard adds count copies of the card with the given DBF ID to the deck.
+//
+// Like the other mutators, AddCard leaves the deck's cards in canonical form:
+// ordered by DBF ID ascending with a single entry per DBF ID. Returns an error
+// if count is 0.
+func (d *Deck) AddCard(dbfID, count uint64) error {
+	if count < 1 {
+		return fmt.Errorf("add card: invalid count for DBF ID %d: %d", dbfID, count)
+	}
+
+	counts := cardCountsByID(d.Cards)
+	counts[dbfID] += count
+	d.setCards(counts)
+	return nil
+}
+
+// RemoveCard removes count copies of the card with the given DBF ID from the
+// deck, removing the card entirely once no copies remain. Returns an error if
+// count is 0 or if the deck has fewer than count copies of the card.
+func (d *Deck) RemoveCard(dbfID, count uint64) error {
+	if count < 1 {
+		return fmt.Errorf("remove card: invalid count for DBF ID %d: %d", dbfID, count)
+	}
+
+	counts := cardCountsByID(d.Cards)
+	if counts[dbfID] < count {
+		return fmt.Errorf("remove card: deck has %d copies of DBF ID %d, cannot remove %d", counts[dbfID], dbfID, count)
+	}
+
+	counts[dbfID] -= count
+	if counts[dbfID] == 0 {
+		delete(counts, dbfID)
+	}
+	d.setCards(counts)
+	return nil
+}
+
+// SetCount sets the number of copies of the card with the given DBF ID. A count
+// of 0 removes the card from the deck.
+func (d *Deck) SetCount(dbfID, count uint64) {
+	counts := cardCountsByID(d.Cards)
+	if count == 0 {
+		delete(counts, dbfID)
+	} else {
+		counts[dbfID] = count
+	}
+	d.setCards(counts)
+}
+
+// setCards replaces the deck's cards with the given counts in DBF ID order.
+func (d *Deck) setCards(counts map[uint64]uint64) {
+	cards := make([][2]uint64, 0, len(counts))
+	for _, dbfID := range sortedKeys(counts) {
+		cards = append(cards, [2]uint64{dbfID, counts[dbfID]})
+	}
+	d.Cards = cards
+}
@@ -0,0 +1,52 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddCard(t *testing.T) {
+	var deck Deck
+	assert.Nil(t, deck.AddCard(455, 1))
+	assert.Nil(t, deck.AddCard(141, 1))
+	assert.Nil(t, deck.AddCard(141, 1))
+	assert.Equal(t, [][2]uint64{{141, 2}, {455, 1}}, deck.Cards)
+
+	assert.NotNil(t, deck.AddCard(216, 0))
+	assert.Equal(t, [][2]uint64{{141, 2}, {455, 1}}, deck.Cards)
+}
+
+func TestAddCardMergesDuplicates(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{455, 1}, {141, 1}, {455, 1}}}
+	assert.Nil(t, deck.AddCard(141, 1))
+	assert.Equal(t, [][2]uint64{{141, 2}, {455, 2}}, deck.Cards)
+}
+
+func TestRemoveCard(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{141, 2}, {455, 1}}}
+	assert.Nil(t, deck.RemoveCard(141, 1))
+	assert.Equal(t, [][2]uint64{{141, 1}, {455, 1}}, deck.Cards)
+
+	assert.Nil(t, deck.RemoveCard(455, 1))
+	assert.Equal(t, [][2]uint64{{141, 1}}, deck.Cards)
+
+	assert.NotNil(t, deck.RemoveCard(141, 2))
+	assert.NotNil(t, deck.RemoveCard(999, 1))
+	assert.NotNil(t, deck.RemoveCard(141, 0))
+	assert.Equal(t, [][2]uint64{{141, 1}}, deck.Cards)
+}
+
+func TestSetCount(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{455, 1}, {141, 2}}}
+	deck.SetCount(216, 3)
+	assert.Equal(t, [][2]uint64{{141, 2}, {216, 3}, {455, 1}}, deck.Cards)
+
+	deck.SetCount(141, 1)
+	deck.SetCount(455, 0)
+	assert.Equal(t, [][2]uint64{{141, 1}, {216, 3}}, deck.Cards)
+
+	deck.SetCount(999, 0)
+	assert.Equal(t, [][2]uint64{{141, 1}, {216, 3}}, deck.Cards)
+}
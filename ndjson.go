@@ -0,0 +1,79 @@
+package deckstrings
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// NDJSONWriter writes decks as newline-delimited JSON, one deck per line, for
+// consumption by data pipelines and tools like jq. Each line is an object of
+// the form:
+//
+//	{"deckstring":"AAECAR8...","format":2,"format_name":"Standard","heroes":[31],"cards":[[141,2],[216,2]]}
+//
+// Records written with WriteRecord also include their "line" number and, for
+// records that failed to decode, an "error" message in place of the deck.
+type NDJSONWriter struct {
+	encoder *json.Encoder
+}
+
+type ndjsonDeck struct {
+	Line       int         `json:"line,omitempty"`
+	Deckstring string      `json:"deckstring"`
+	Format     uint64      `json:"format"`
+	FormatName string      `json:"format_name"`
+	Heroes     []uint64    `json:"heroes"`
+	Cards      [][2]uint64 `json:"cards"`
+}
+
+type ndjsonError struct {
+	Line       int    `json:"line,omitempty"`
+	Deckstring string `json:"deckstring"`
+	Error      string `json:"error"`
+}
+
+// NewNDJSONWriter creates an NDJSONWriter writing to writer.
+func NewNDJSONWriter(writer io.Writer) *NDJSONWriter {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &NDJSONWriter{encoder: encoder}
+}
+
+// Write writes a deck along with its deckstring as a single line of JSON.
+func (w *NDJSONWriter) Write(deckstring string, deck Deck) error {
+	return w.encoder.Encode(newNDJSONDeck(deckstring, deck))
+}
+
+// WriteRecord writes a record read by a DeckReader as a single line of JSON.
+func (w *NDJSONWriter) WriteRecord(record Record) error {
+	if record.Err != nil {
+		return w.encoder.Encode(ndjsonError{
+			Line:       record.Line,
+			Deckstring: record.Deckstring,
+			Error:      record.Err.Error(),
+		})
+	}
+
+	line := newNDJSONDeck(record.Deckstring, record.Deck)
+	line.Line = record.Line
+	return w.encoder.Encode(line)
+}
+
+func newNDJSONDeck(deckstring string, deck Deck) ndjsonDeck {
+	// Always write heroes and cards as arrays, even when empty.
+	heroes, cards := deck.Heroes, deck.Cards
+	if heroes == nil {
+		heroes = []uint64{}
+	}
+	if cards == nil {
+		cards = [][2]uint64{}
+	}
+
+	return ndjsonDeck{
+		Deckstring: deckstring,
+		Format:     uint64(deck.Format),
+		FormatName: deck.Format.String(),
+		Heroes:     heroes,
+		Cards:      cards,
+	}
+}
@@ -0,0 +1,48 @@
+package deckstrings_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+
+	deck := Deck{Format: FormatStandard, Heroes: []uint64{31}, Cards: [][2]uint64{{141, 2}, {455, 1}}}
+	assert.Nil(t, writer.Write("AAECAR8BxwMBjQEA", deck))
+	assert.Nil(t, writer.Write("AAEAAAAAAA==", Deck{}))
+
+	expected := `{"deckstring":"AAECAR8BxwMBjQEA","format":2,"format_name":"Standard","heroes":[31],"cards":[[141,2],[455,1]]}
+{"deckstring":"AAEAAAAAAA==","format":0,"format_name":"Format(0)","heroes":[],"cards":[]}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestNDJSONWriterRecords(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+
+	reader := NewDeckReader(strings.NewReader("AAEAAAAAAA==\n+/+/\n"))
+	for reader.Next() {
+		assert.Nil(t, writer.WriteRecord(reader.Record()))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, `{"line":1,"deckstring":"AAEAAAAAAA==","format":0,"format_name":"Format(0)","heroes":[],"cards":[]}`, lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], `{"line":2,"deckstring":"+/+/","error":"deckstring decode: `), lines[1])
+}
+
+func ExampleNDJSONWriter() {
+	writer := NewNDJSONWriter(os.Stdout)
+	deck := Deck{Format: FormatWild, Heroes: []uint64{637}, Cards: [][2]uint64{{315, 2}}}
+	writer.Write("AAEBAf0EAAG7AgA=", deck)
+	// Output:
+	// {"deckstring":"AAEBAf0EAAG7AgA=","format":1,"format_name":"Wild","heroes":[637],"cards":[[315,2]]}
+}
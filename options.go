@@ -0,0 +1,294 @@
+package deckstrings
+
+import "log/slog"
+
+// DecodeOption configures optional behavior of Decode.
+type DecodeOption func(*decodeOptions)
+
+// DefaultMaxPayloadLength is the default limit on the length in bytes of a
+// base64-decoded deckstring payload. Real decks, including those with
+// sideboards, are a few hundred bytes at most.
+const DefaultMaxPayloadLength = 4096
+
+type decodeOptions struct {
+	checkFormat      bool
+	formats          []Format
+	keepDuplicates   bool
+	maxPayloadLength int
+	maxHeroes        int
+	maxDistinctCards int
+	maxTotalCount    uint64
+	strictVarints    bool
+	maxDBFID         uint64
+	rejectTrailing   bool
+	newerVersions    bool
+	anyReserved      bool
+	logger           *slog.Logger
+	workers          int
+}
+
+func newDecodeOptions(opts []DecodeOption) *decodeOptions {
+	o := &decodeOptions{maxPayloadLength: DefaultMaxPayloadLength}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// RequireFormat rejects deckstrings whose format is not one of the given formats.
+// With no arguments, the format must be one known to this package (FormatWild,
+// FormatStandard, FormatClassic, or FormatTwist).
+func RequireFormat(formats ...Format) DecodeOption {
+	return func(o *decodeOptions) {
+		o.checkFormat = true
+		o.formats = formats
+	}
+}
+
+// KeepDuplicates disables merging of duplicate card entries. A deckstring may
+// list the same DBF ID more than once, e.g. in both the 1x and 2x groups. By
+// default Decode sums such entries so each DBF ID appears once in Cards; with
+// KeepDuplicates each entry is returned as encoded, which preserves the
+// deckstring's structure for tooling that inspects it.
+func KeepDuplicates() DecodeOption {
+	return func(o *decodeOptions) {
+		o.keepDuplicates = true
+	}
+}
+
+// MaxPayloadLength limits the length in bytes of the base64-decoded payload,
+// bounding the work and memory spent decoding untrusted input. Hero counts and
+// group lengths that could not fit in the limit are rejected before anything
+// is allocated for them. The default is DefaultMaxPayloadLength; 0 removes the
+// limit.
+func MaxPayloadLength(n int) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxPayloadLength = n
+	}
+}
+
+// MaxHeroes rejects deckstrings with more than n heroes. 0 means no limit,
+// which is the default.
+func MaxHeroes(n int) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxHeroes = n
+	}
+}
+
+// MaxDistinctCards rejects deckstrings listing more than n distinct DBF IDs.
+// 0 means no limit, which is the default.
+func MaxDistinctCards(n int) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxDistinctCards = n
+	}
+}
+
+// MaxTotalCount rejects deckstrings whose card counts sum to more than n. 0
+// means no limit, which is the default. For example, a ladder tool might
+// decode with MaxHeroes(1), MaxTotalCount(40) while a research pipeline
+// leaves both unset.
+func MaxTotalCount(n uint64) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxTotalCount = n
+	}
+}
+
+// StrictVarints rejects deckstrings containing overlong varints: values
+// encoded with more bytes than their minimal encoding, such as 0x80 0x00 for
+// 0. Such deckstrings decode to the same deck as their minimal form, so
+// rejecting them keeps accepted deckstrings canonical. Without this option
+// overlong varints are reported as warnings.
+func StrictVarints() DecodeOption {
+	return func(o *decodeOptions) {
+		o.strictVarints = true
+	}
+}
+
+// MaxDBFID rejects deckstrings with a hero or card DBF ID greater than n,
+// catching values that can't correspond to real cards. 0 means no limit,
+// which is the default.
+func MaxDBFID(n uint64) DecodeOption {
+	return func(o *decodeOptions) {
+		o.maxDBFID = n
+	}
+}
+
+// RejectTrailingData rejects deckstrings with bytes after the last card group,
+// which usually indicate corruption. The single zero byte newer clients write
+// to mark a deck without sideboards is allowed. Since sideboards are not
+// decoded by this package, decks with sideboards are rejected.
+//
+// By default trailing data, such as groups added by a newer revision of the
+// format, is skipped so decoding still succeeds. DecodeWithInfo returns it
+// unparsed in DecodeInfo.Trailing and reports it as WarningTrailingData.
+func RejectTrailingData() DecodeOption {
+	return func(o *decodeOptions) {
+		o.rejectTrailing = true
+	}
+}
+
+// DecodeNewerVersions decodes deckstrings with a version newer than Version,
+// and without a codec registered with RegisterCodec, on a best-effort basis
+// rather than failing. The payload is decoded as far as it follows the
+// version 1 structure, so tools keep working after game patches introduce a
+// new version. DecodeWithInfo reports WarningNewerVersion, along with
+// WarningTrailingData for any data past the version 1 card groups. Decoding
+// still fails if the payload doesn't follow the version 1 structure. The
+// deck's Version is left 0, so Encode writes it as version 1, and
+// DecodeInfo.Version holds the version read.
+func DecodeNewerVersions() DecodeOption {
+	return func(o *decodeOptions) {
+		o.newerVersions = true
+	}
+}
+
+// AllowReserved accepts deckstrings whose reserved leading field is not zero,
+// in case a future revision of the format starts using it. The value is
+// reported in DecodeInfo.Reserved. See EncodeReserved.
+func AllowReserved() DecodeOption {
+	return func(o *decodeOptions) {
+		o.anyReserved = true
+	}
+}
+
+// DecodeLogger logs decoding to logger: failures at slog.LevelWarn and each
+// Warning at slog.LevelDebug. It is most useful with batch components such as
+// DeckReader, which add the line number and deckstring of each record to the
+// log entries. Nothing is logged by default.
+func DecodeLogger(logger *slog.Logger) DecodeOption {
+	return func(o *decodeOptions) {
+		o.logger = logger
+	}
+}
+
+// DecodeWorkers sets the number of deckstrings DecodeAll decodes in parallel.
+// The default, or any n less than 1, is runtime.GOMAXPROCS(0). Other
+// functions ignore it.
+func DecodeWorkers(n int) DecodeOption {
+	return func(o *decodeOptions) {
+		o.workers = n
+	}
+}
+
+func (o *decodeOptions) allowsFormat(format Format) bool {
+	if !o.checkFormat {
+		return true
+	}
+
+	if len(o.formats) == 0 {
+		return format.Known()
+	}
+
+	for _, allowed := range o.formats {
+		if format == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeOption configures optional behavior of Encode.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	duplicates DuplicatePolicy
+	groups     map[uint64]int
+	compress   bool
+	version    uint64
+	pinVersion bool
+	reserved   uint64
+	workers    int
+}
+
+func newEncodeOptions(opts []EncodeOption) *encodeOptions {
+	o := &encodeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// DuplicatePolicy determines how Encode handles a deck listing the same DBF ID
+// more than once in Cards.
+type DuplicatePolicy int
+
+const (
+	// DuplicatesMerge sums the counts of duplicate entries and encodes each
+	// DBF ID once. This is the default and produces canonical deckstrings:
+	// decoding the result gives Canonicalize(deck).
+	DuplicatesMerge DuplicatePolicy = iota
+
+	// DuplicatesError fails to encode a deck with duplicate entries. Decks
+	// that do encode round-trip exactly (up to ordering).
+	DuplicatesError
+
+	// DuplicatesKeep encodes every entry as given, producing a non-canonical
+	// deckstring. Decoding the result with KeepDuplicates gives back every
+	// entry (up to ordering); decoding without it merges them.
+	DuplicatesKeep
+)
+
+// OnDuplicates sets the policy for duplicate DBF IDs. See DuplicatePolicy.
+func OnDuplicates(policy DuplicatePolicy) EncodeOption {
+	return func(o *encodeOptions) {
+		o.duplicates = policy
+	}
+}
+
+// AssignGroups overrides the card group each given DBF ID is encoded in: 1 for
+// 1x cards, 2 for 2x cards, or 3 for cards with an explicit count. Cards not
+// in groups use the usual group for their count. A card may only be assigned
+// to group 1 or 2 if its count matches, but any card may be assigned to group
+// 3. This is for reproducing byte-exact deckstrings from other encoders; see
+// DecodeInfo.Entries for the groups of a decoded deckstring. Encodings with
+// overridden groups are not canonical.
+func AssignGroups(groups map[uint64]int) EncodeOption {
+	return func(o *encodeOptions) {
+		o.groups = groups
+	}
+}
+
+// Compress deflates the deckstring payload when that makes the deckstring
+// shorter, which helps decks with many distinct cards such as those found in
+// research datasets. Decks that don't benefit, including all ordinary decks,
+// are encoded as usual. Decode detects compressed deckstrings automatically.
+//
+// Compressed deckstrings can't be imported into the game. Compress applies to
+// Encode and EncodeTo; containers like deck lists ignore it.
+func Compress() EncodeOption {
+	return func(o *encodeOptions) {
+		o.compress = true
+	}
+}
+
+// EncodeVersion pins the deckstring encoding version written by Encode, so
+// output doesn't change if a later release of this package defaults to a
+// newer version. Encoding fails if the version is not supported, either
+// built in or registered with RegisterCodec, or if the deck can't be
+// represented in it. The default is the deck's Version field, or Version if
+// that is 0.
+func EncodeVersion(version uint64) EncodeOption {
+	return func(o *encodeOptions) {
+		o.version = version
+		o.pinVersion = true
+	}
+}
+
+// EncodeReserved writes value in the reserved leading field, which is
+// otherwise 0, for experimenting with future revisions of the format. Such
+// deckstrings can only be decoded with AllowReserved and are unlikely to be
+// accepted by the game.
+func EncodeReserved(value uint64) EncodeOption {
+	return func(o *encodeOptions) {
+		o.reserved = value
+	}
+}
+
+// EncodeWorkers sets the number of decks EncodeAll encodes in parallel. The
+// default, or any n less than 1, is runtime.GOMAXPROCS(0). Other functions
+// ignore it.
+func EncodeWorkers(n int) EncodeOption {
+	return func(o *encodeOptions) {
+		o.workers = n
+	}
+}
@@ -0,0 +1,113 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type identifiers.
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// thriftStruct writes a struct using the Thrift compact protocol, which is how
+// Parquet encodes its page headers and file metadata. Fields must be written
+// in ascending ID order and the struct finished with end.
+type thriftStruct struct {
+	buf  *bytes.Buffer
+	last int16
+}
+
+func newThriftStruct(buf *bytes.Buffer) *thriftStruct {
+	return &thriftStruct{buf: buf}
+}
+
+func (s *thriftStruct) field(id int16, kind byte) {
+	if delta := id - s.last; delta > 0 && delta <= 15 {
+		s.buf.WriteByte(byte(delta)<<4 | kind)
+	} else {
+		s.buf.WriteByte(kind)
+		s.varint(int64(id))
+	}
+	s.last = id
+}
+
+func (s *thriftStruct) varint(value int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], value)
+	s.buf.Write(buf[:n])
+}
+
+func (s *thriftStruct) listHeader(size int, kind byte) {
+	if size < 15 {
+		s.buf.WriteByte(byte(size)<<4 | kind)
+	} else {
+		var buf [binary.MaxVarintLen64]byte
+		s.buf.WriteByte(0xf0 | kind)
+		n := binary.PutUvarint(buf[:], uint64(size))
+		s.buf.Write(buf[:n])
+	}
+}
+
+func (s *thriftStruct) bytes(value []byte) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(value)))
+	s.buf.Write(buf[:n])
+	s.buf.Write(value)
+}
+
+func (s *thriftStruct) i32(id int16, value int32) {
+	s.field(id, compactI32)
+	s.varint(int64(value))
+}
+
+func (s *thriftStruct) i64(id int16, value int64) {
+	s.field(id, compactI64)
+	s.varint(value)
+}
+
+func (s *thriftStruct) string(id int16, value string) {
+	s.field(id, compactBinary)
+	s.bytes([]byte(value))
+}
+
+func (s *thriftStruct) structure(id int16, write func(*thriftStruct)) {
+	s.field(id, compactStruct)
+	nested := newThriftStruct(s.buf)
+	write(nested)
+	nested.end()
+}
+
+func (s *thriftStruct) i32List(id int16, values []int32) {
+	s.field(id, compactList)
+	s.listHeader(len(values), compactI32)
+	for _, value := range values {
+		s.varint(int64(value))
+	}
+}
+
+func (s *thriftStruct) stringList(id int16, values []string) {
+	s.field(id, compactList)
+	s.listHeader(len(values), compactBinary)
+	for _, value := range values {
+		s.bytes([]byte(value))
+	}
+}
+
+func (s *thriftStruct) structList(id int16, size int, write func(int, *thriftStruct)) {
+	s.field(id, compactList)
+	s.listHeader(size, compactStruct)
+	for i := 0; i < size; i++ {
+		nested := newThriftStruct(s.buf)
+		write(i, nested)
+		nested.end()
+	}
+}
+
+func (s *thriftStruct) end() {
+	s.buf.WriteByte(0)
+}
@@ -0,0 +1,279 @@
+// Package parquet exports decoded Hearthstone decks to Apache Parquet files,
+// so deck datasets can be loaded directly into tools like Spark or DuckDB.
+//
+// Files have one row per card in each deck, with the following schema:
+//
+//	message deck {
+//	  required int64 deck;               // Index of the deck within the file, from 0.
+//	  required binary deckstring (UTF8); // Deckstring as given to Writer.Write.
+//	  required int64 format;             // Deck format, e.g. 2 for Standard.
+//	  required int64 hero;               // DBF ID of the deck's first hero, or 0.
+//	  required int64 dbf_id;             // DBF ID of the card.
+//	  required int64 count;              // Number of copies of the card.
+//	}
+//
+// Decks without cards produce no rows, but still consume a deck index. Values
+// are PLAIN encoded and uncompressed.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/schmich/deckstrings"
+)
+
+// DefaultRowGroupSize is the default maximum number of rows in a row group.
+const DefaultRowGroupSize = 64 * 1024
+
+const magic = "PAR1"
+
+// Parquet physical types, encodings, and other enumerations used by Writer.
+const (
+	typeInt64     = 2
+	typeByteArray = 6
+
+	repetitionRequired = 0
+	convertedUTF8      = 0
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+)
+
+type column struct {
+	name     string
+	kind     int32
+	values   bytes.Buffer
+	utf8     bool
+	metadata []chunkMetadata
+}
+
+type chunkMetadata struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+type rowGroup struct {
+	numRows int64
+	size    int64
+}
+
+// Writer writes decks to a Parquet file. Rows are buffered in memory and
+// written in row groups of up to RowGroupSize rows. Close must be called to
+// write the file footer; the file is not valid until then.
+type Writer struct {
+	// RowGroupSize is the maximum number of rows in a row group. It may be
+	// changed before the first call to Write.
+	RowGroupSize int
+
+	writer    io.Writer
+	offset    int64
+	decks     int64
+	rows      int
+	columns   []*column
+	rowGroups []rowGroup
+	started   bool
+	closed    bool
+}
+
+// NewWriter creates a Writer writing a Parquet file to writer.
+func NewWriter(writer io.Writer) *Writer {
+	return &Writer{
+		RowGroupSize: DefaultRowGroupSize,
+		writer:       writer,
+		columns: []*column{
+			{name: "deck", kind: typeInt64},
+			{name: "deckstring", kind: typeByteArray, utf8: true},
+			{name: "format", kind: typeInt64},
+			{name: "hero", kind: typeInt64},
+			{name: "dbf_id", kind: typeInt64},
+			{name: "count", kind: typeInt64},
+		},
+	}
+}
+
+// Write adds the rows for a deck along with its deckstring.
+func (w *Writer) Write(deckstring string, deck deckstrings.Deck) error {
+	if w.closed {
+		return errors.New("parquet: write to closed writer")
+	}
+
+	hero := uint64(0)
+	if len(deck.Heroes) > 0 {
+		hero = deck.Heroes[0]
+	}
+
+	for _, card := range deck.Cards {
+		w.writeInt64(0, w.decks)
+		w.writeString(1, deckstring)
+		w.writeInt64(2, int64(deck.Format))
+		w.writeInt64(3, int64(hero))
+		w.writeInt64(4, int64(card[0]))
+		w.writeInt64(5, int64(card[1]))
+		w.rows++
+
+		if w.rows >= w.RowGroupSize {
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.decks++
+	return nil
+}
+
+// Close writes any buffered rows and the file footer. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	var footer bytes.Buffer
+	w.writeFileMetadata(&footer)
+
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(footer.Len()))
+	footer.Write(length)
+	footer.WriteString(magic)
+
+	return w.write(footer.Bytes())
+}
+
+func (w *Writer) writeInt64(column int, value int64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(value))
+	w.columns[column].values.Write(buf[:])
+}
+
+func (w *Writer) writeString(column int, value string) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(len(value)))
+	w.columns[column].values.Write(buf[:])
+	w.columns[column].values.WriteString(value)
+}
+
+func (w *Writer) write(data []byte) error {
+	n, err := w.writer.Write(data)
+	w.offset += int64(n)
+	return err
+}
+
+// start writes the leading magic bytes, if not yet written.
+func (w *Writer) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	return w.write([]byte(magic))
+}
+
+// flush writes the buffered rows as a row group, with one data page per column.
+func (w *Writer) flush() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	group := rowGroup{numRows: int64(w.rows)}
+	for _, column := range w.columns {
+		var header bytes.Buffer
+		s := newThriftStruct(&header)
+		s.i32(1, pageTypeData)
+		s.i32(2, int32(column.values.Len()))
+		s.i32(3, int32(column.values.Len()))
+		s.structure(5, func(s *thriftStruct) {
+			s.i32(1, int32(w.rows))
+			s.i32(2, encodingPlain)
+			s.i32(3, encodingRLE)
+			s.i32(4, encodingRLE)
+		})
+		s.end()
+
+		chunk := chunkMetadata{
+			offset:    w.offset,
+			size:      int64(header.Len() + column.values.Len()),
+			numValues: int64(w.rows),
+		}
+
+		if err := w.write(header.Bytes()); err != nil {
+			return err
+		}
+		if err := w.write(column.values.Bytes()); err != nil {
+			return err
+		}
+
+		column.metadata = append(column.metadata, chunk)
+		column.values.Reset()
+		group.size += chunk.size
+	}
+
+	w.rowGroups = append(w.rowGroups, group)
+	w.rows = 0
+	return nil
+}
+
+func (w *Writer) writeFileMetadata(buf *bytes.Buffer) {
+	numRows := int64(0)
+	for _, group := range w.rowGroups {
+		numRows += group.numRows
+	}
+
+	s := newThriftStruct(buf)
+	s.i32(1, 1)
+	s.structList(2, len(w.columns)+1, func(i int, s *thriftStruct) {
+		if i == 0 {
+			s.string(4, "deck")
+			s.i32(5, int32(len(w.columns)))
+			return
+		}
+
+		column := w.columns[i-1]
+		s.i32(1, column.kind)
+		s.i32(3, repetitionRequired)
+		s.string(4, column.name)
+		if column.utf8 {
+			s.i32(6, convertedUTF8)
+		}
+	})
+	s.i64(3, numRows)
+	s.structList(4, len(w.rowGroups), func(i int, s *thriftStruct) {
+		group := w.rowGroups[i]
+		s.structList(1, len(w.columns), func(j int, s *thriftStruct) {
+			column := w.columns[j]
+			chunk := column.metadata[i]
+			s.i64(2, chunk.offset)
+			s.structure(3, func(s *thriftStruct) {
+				s.i32(1, column.kind)
+				s.i32List(2, []int32{encodingPlain})
+				s.stringList(3, []string{column.name})
+				s.i32(4, codecUncompressed)
+				s.i64(5, chunk.numValues)
+				s.i64(6, chunk.size)
+				s.i64(7, chunk.size)
+				s.i64(9, chunk.offset)
+			})
+		})
+		s.i64(2, group.size)
+		s.i64(3, group.numRows)
+	})
+	s.string(6, "github.com/schmich/deckstrings/parquet")
+	s.end()
+}
@@ -0,0 +1,96 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/parquet"
+	"github.com/stretchr/testify/assert"
+)
+
+func footer(t *testing.T, file []byte) []byte {
+	assert.True(t, bytes.HasPrefix(file, []byte("PAR1")))
+	assert.True(t, bytes.HasSuffix(file, []byte("PAR1")))
+
+	length := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	assert.True(t, length > 0 && length <= len(file)-12)
+	return file[len(file)-8-length : len(file)-8]
+}
+
+func TestWriter(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck, err := deckstrings.Decode(deckstring)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	writer := parquet.NewWriter(&buf)
+	assert.Nil(t, writer.Write(deckstring, deck))
+	assert.Nil(t, writer.Write("AAEAAAAAAA==", deckstrings.Deck{}))
+	assert.Nil(t, writer.Close())
+
+	file := buf.Bytes()
+	metadata := footer(t, file)
+	for _, name := range []string{"deck", "deckstring", "format", "hero", "dbf_id", "count"} {
+		assert.True(t, bytes.Contains(metadata, []byte(name)), name)
+	}
+
+	// One PLAIN encoded deckstring value per card; the empty deck has no rows.
+	assert.Equal(t, len(deck.Cards), bytes.Count(file, []byte(deckstring)))
+	assert.Equal(t, 0, bytes.Count(file, []byte("AAEAAAAAAA==")))
+}
+
+// testdata/decks.parquet was written by TestWriterGolden's decks and checked
+// with github.com/parquet-go/parquet-go v0.32.0, which reads back its schema,
+// 6 row groups, and 22 rows with the expected values. Regenerate and recheck
+// it with a real Parquet reader if the writer's output changes.
+func TestWriterGolden(t *testing.T) {
+	var buf bytes.Buffer
+	writer := parquet.NewWriter(&buf)
+	writer.RowGroupSize = 4
+	for _, deckstring := range []string{
+		"AAEBAQcAAAQBAwIDAwMEAw==",
+		"AAEAAAAAAA==",
+		"AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=",
+	} {
+		deck, err := deckstrings.Decode(deckstring)
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Write(deckstring, deck))
+	}
+	assert.Nil(t, writer.Close())
+
+	golden, err := os.ReadFile("testdata/decks.parquet")
+	assert.Nil(t, err)
+	assert.Equal(t, golden, buf.Bytes())
+}
+
+func TestWriterRowGroups(t *testing.T) {
+	deck := deckstrings.Deck{Heroes: []uint64{31}, Cards: [][2]uint64{{1, 1}, {2, 2}, {3, 1}}}
+
+	var single, split bytes.Buffer
+
+	writer := parquet.NewWriter(&single)
+	assert.Nil(t, writer.Write("a", deck))
+	assert.Nil(t, writer.Close())
+
+	writer = parquet.NewWriter(&split)
+	writer.RowGroupSize = 1
+	assert.Nil(t, writer.Write("a", deck))
+	assert.Nil(t, writer.Close())
+
+	// Each row group repeats the page headers and column metadata.
+	assert.True(t, split.Len() > single.Len())
+	assert.True(t, len(footer(t, split.Bytes())) > len(footer(t, single.Bytes())))
+}
+
+func TestWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	writer := parquet.NewWriter(&buf)
+	assert.Nil(t, writer.Close())
+	footer(t, buf.Bytes())
+
+	assert.Nil(t, writer.Close())
+	assert.NotNil(t, writer.Write("a", deckstrings.Deck{}))
+}
@@ -0,0 +1,202 @@
+package deckstrings
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FieldKind identifies how a single varint in a deckstring is interpreted.
+type FieldKind int
+
+const (
+	FieldReserved FieldKind = iota
+	FieldVersion
+	FieldFormat
+	FieldHeroCount
+	FieldHero
+	FieldGroupLength
+	FieldCard
+	FieldCount
+)
+
+var fieldKindNames = map[FieldKind]string{
+	FieldReserved:    "reserved",
+	FieldVersion:     "version",
+	FieldFormat:      "format",
+	FieldHeroCount:   "hero count",
+	FieldHero:        "hero",
+	FieldGroupLength: "group length",
+	FieldCard:        "card",
+	FieldCount:       "count",
+}
+
+func (k FieldKind) String() string {
+	if name, ok := fieldKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("FieldKind(%d)", int(k))
+}
+
+// Field is a single varint read from a deckstring along with its location in
+// the base64-decoded payload.
+//
+// Group is the card group the field belongs to: 1 for 1x cards, 2 for 2x cards,
+// and 3 for cards with an explicit count. It is 0 for header and hero fields.
+type Field struct {
+	Kind   FieldKind
+	Offset int
+	Length int
+	Value  uint64
+	Group  int
+}
+
+// newPayloadReader returns a reader over the base64-decoded bytes of a deckstring.
+func newPayloadReader(deckstring string) io.ByteReader {
+	return bufio.NewReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(deckstring)))
+}
+
+// newPayloadBytesReader is like newPayloadReader for a deckstring held in a
+// byte slice.
+func newPayloadBytesReader(deckstring []byte) io.ByteReader {
+	return bufio.NewReader(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(deckstring)))
+}
+
+// parser reads the fields of a deckstring payload in wire order, passing each
+// to visit as it is read. Parsing stops at the first error, including any
+// error returned by visit.
+type parser struct {
+	parseOptions
+	varint *varintReader
+	visit  func(Field) error
+}
+
+// parseOptions relaxes the checks made while parsing.
+type parseOptions struct {
+	// newerVersions accepts versions newer than Version, parsing them with
+	// the version 1 structure.
+	newerVersions bool
+
+	// anyReserved accepts a non-zero reserved field.
+	anyReserved bool
+}
+
+func parse(reader io.ByteReader, visit func(Field) error) error {
+	return parseWith(reader, parseOptions{}, visit)
+}
+
+func parseWith(reader io.ByteReader, options parseOptions, visit func(Field) error) error {
+	p := &parser{parseOptions: options, varint: &varintReader{reader: reader}, visit: visit}
+	return p.parse()
+}
+
+// parseDeckstring parses the fields of a deckstring for the functions that
+// visit fields instead of building a Deck. Like Decode, it inflates compressed
+// deckstrings and honors MaxPayloadLength, DecodeNewerVersions, and
+// AllowReserved. A deckstring whose version has a codec registered with
+// RegisterCodec has no fields to visit, so it is decoded with that codec and
+// returned as a deck instead of calling visit.
+func parseDeckstring(deckstring string, options *decodeOptions, visit func(Field) error) (*Deck, error) {
+	reader, err := decompress(newPayloadReader(deckstring))
+	if err != nil {
+		return nil, err
+	}
+
+	reader, version := peekVersion(reader)
+	if c, ok := lookupCodec(version); ok {
+		if _, builtIn := c.(versionOneCodec); !builtIn {
+			deck, _, err := c.decode(reader, options)
+			if err != nil {
+				return nil, err
+			}
+			return &deck, nil
+		}
+	}
+
+	// As in Decode, the limit applies to the decompressed payload.
+	limited := &limitedReader{reader: reader, limit: options.maxPayloadLength}
+	relaxed := parseOptions{newerVersions: options.newerVersions, anyReserved: options.anyReserved}
+	return nil, parseWith(limited, relaxed, visit)
+}
+
+func (p *parser) read(kind FieldKind, group int) (uint64, error) {
+	offset := p.varint.offset
+	value, err := p.varint.Read()
+	if err != nil {
+		return 0, err
+	}
+
+	field := Field{
+		Kind:   kind,
+		Offset: offset,
+		Length: p.varint.offset - offset,
+		Value:  value,
+		Group:  group,
+	}
+
+	if err := p.visit(field); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (p *parser) parse() error {
+	reserved, err := p.read(FieldReserved, 0)
+	if err != nil {
+		return err
+	}
+
+	if reserved != 0 && !p.anyReserved {
+		return fmt.Errorf("unexpected reserved byte: %d", reserved)
+	}
+
+	version, err := p.read(FieldVersion, 0)
+	if err != nil {
+		return err
+	}
+
+	if version != Version && !(p.newerVersions && version > Version) {
+		return fmt.Errorf("unsupported version: %d", version)
+	}
+
+	if _, err := p.read(FieldFormat, 0); err != nil {
+		return err
+	}
+
+	length, err := p.read(FieldHeroCount, 0)
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < length; i++ {
+		if _, err := p.read(FieldHero, 0); err != nil {
+			return err
+		}
+	}
+
+	for group := 1; group <= 3; group++ {
+		length, err := p.read(FieldGroupLength, group)
+		if err != nil {
+			return err
+		}
+
+		for i := uint64(0); i < length; i++ {
+			if _, err := p.read(FieldCard, group); err != nil {
+				return err
+			}
+
+			// Cards in the third group carry an explicit count.
+			if group >= 3 {
+				if _, err := p.read(FieldCount, group); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
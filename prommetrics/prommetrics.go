@@ -0,0 +1,112 @@
+// Package prommetrics reports deckstring telemetry to Prometheus. Its
+// Collector implements deckstrings.Metrics, exposing operation counts, error
+// counts by type, and latency histograms for decoding, encoding, and requests
+// made by subpackages such as blizzard.
+//
+// deckstrings has no HTTP service, so there are no per-endpoint request
+// metrics. A program that serves decode and encode endpoints gets their
+// counts, errors, and latencies by registering a Collector as below, since
+// every Decode and Encode call reports to the registered Metrics.
+//
+// prommetrics is a separate module so that only programs that import it
+// depend on the Prometheus client.
+//
+//	collector := prommetrics.NewCollector("deckstrings")
+//	prometheus.MustRegister(collector)
+//	deckstrings.RegisterMetrics(collector)
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/schmich/deckstrings"
+)
+
+// Collector is a prometheus.Collector that records observations as the
+// metrics:
+//
+//	<namespace>_operations_total{operation}
+//	<namespace>_errors_total{operation, type}
+//	<namespace>_operation_duration_seconds{operation}
+//
+// where operation is "decode", "encode", or a request operation, and type is
+// the error's deckstrings.ErrorType. It is safe for concurrent use.
+type Collector struct {
+	operations *prometheus.CounterVec
+	errors     *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+}
+
+var _ deckstrings.Metrics = (*Collector)(nil)
+
+// DefaultBuckets are the latency histogram buckets, in seconds, used by
+// NewCollector. Decoding and encoding take microseconds; requests take
+// milliseconds to seconds.
+var DefaultBuckets = prometheus.ExponentialBuckets(0.000001, 4, 13)
+
+// NewCollector creates a Collector whose metrics are prefixed by namespace,
+// using DefaultBuckets.
+func NewCollector(namespace string) *Collector {
+	return NewCollectorWithBuckets(namespace, DefaultBuckets)
+}
+
+// NewCollectorWithBuckets is like NewCollector but with custom latency
+// histogram buckets, in seconds.
+func NewCollectorWithBuckets(namespace string, buckets []float64) *Collector {
+	return &Collector{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operations_total",
+			Help:      "Deckstring operations, including failed operations.",
+		}, []string{"operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Failed deckstring operations by error type.",
+		}, []string{"operation", "type"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Deckstring operation latency.",
+			Buckets:   buckets,
+		}, []string{"operation"}),
+	}
+}
+
+// ObserveDecode records a decode as the operation "decode".
+func (c *Collector) ObserveDecode(duration time.Duration, err error) {
+	c.observe("decode", duration, err)
+}
+
+// ObserveEncode records an encode as the operation "encode".
+func (c *Collector) ObserveEncode(duration time.Duration, err error) {
+	c.observe("encode", duration, err)
+}
+
+// ObserveRequest records a request as the given operation.
+func (c *Collector) ObserveRequest(operation string, duration time.Duration, err error) {
+	c.observe(operation, duration, err)
+}
+
+func (c *Collector) observe(operation string, duration time.Duration, err error) {
+	c.operations.WithLabelValues(operation).Inc()
+	c.durations.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(operation, deckstrings.ErrorType(err)).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.operations.Describe(ch)
+	c.errors.Describe(ch)
+	c.durations.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.operations.Collect(ch)
+	c.errors.Collect(ch)
+	c.durations.Collect(ch)
+}
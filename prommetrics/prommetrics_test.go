@@ -0,0 +1,64 @@
+package prommetrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/schmich/deckstrings"
+	"github.com/schmich/deckstrings/prommetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	collector := prommetrics.NewCollector("deckstrings")
+	registry := prometheus.NewPedanticRegistry()
+	require.Nil(t, registry.Register(collector))
+
+	collector.ObserveDecode(time.Millisecond, nil)
+	collector.ObserveDecode(time.Millisecond, errors.Wrap(deckstrings.UnknownCardError{DBFID: 1}, "deck"))
+	collector.ObserveEncode(time.Millisecond, nil)
+	collector.ObserveRequest("blizzard deck", time.Second, errors.New("timeout"))
+
+	expected := `
+# HELP deckstrings_errors_total Failed deckstring operations by error type.
+# TYPE deckstrings_errors_total counter
+deckstrings_errors_total{operation="blizzard deck",type="invalid"} 1
+deckstrings_errors_total{operation="decode",type="unknown_card"} 1
+# HELP deckstrings_operations_total Deckstring operations, including failed operations.
+# TYPE deckstrings_operations_total counter
+deckstrings_operations_total{operation="blizzard deck"} 1
+deckstrings_operations_total{operation="decode"} 2
+deckstrings_operations_total{operation="encode"} 1
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "deckstrings_operations_total", "deckstrings_errors_total")
+	assert.Nil(t, err)
+
+	count, err := testutil.GatherAndCount(registry, "deckstrings_operation_duration_seconds")
+	require.Nil(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestCollectorRegistered(t *testing.T) {
+	collector := prommetrics.NewCollector("test")
+	deckstrings.RegisterMetrics(collector)
+	t.Cleanup(func() { deckstrings.RegisterMetrics(nil) })
+
+	_, err := deckstrings.Decode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	require.Nil(t, err)
+	_, err = deckstrings.Decode("AAEC")
+	require.NotNil(t, err)
+
+	registry := prometheus.NewRegistry()
+	require.Nil(t, registry.Register(collector))
+	expected := `
+# HELP test_errors_total Failed deckstring operations by error type.
+# TYPE test_errors_total counter
+test_errors_total{operation="decode",type="truncated"} 1
+`
+	assert.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "test_errors_total"))
+}
@@ -0,0 +1,147 @@
+package deckstrings
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// DefaultHeroes maps each class, as named in HearthstoneJSON's cardClass
+// field, to the DBF ID of its original hero.
+var DefaultHeroes = map[string]uint64{
+	"DEATHKNIGHT": 78065, // The Lich King
+	"DEMONHUNTER": 56550, // Illidan Stormrage
+	"DRUID":       274,   // Malfurion Stormrage
+	"HUNTER":      31,    // Rexxar
+	"MAGE":        637,   // Jaina Proudmoore
+	"PALADIN":     671,   // Uther Lightbringer
+	"PRIEST":      813,   // Anduin Wrynn
+	"ROGUE":       930,   // Valeera Sanguinar
+	"SHAMAN":      1066,  // Thrall
+	"WARLOCK":     893,   // Gul'dan
+	"WARRIOR":     7,     // Garrosh Hellscream
+}
+
+// DefaultDeckSize is the number of cards in a constructed deck.
+const DefaultDeckSize = 30
+
+// DeckGenerator generates random decks that follow the constructed deck
+// building rules: a fixed number of collectible cards, at most one copy of
+// each legendary and two copies of any other card, and only cards that are
+// neutral or belong to the deck's class. Hero cards are never chosen.
+//
+// A CardDB has no notion of set rotation, so only Wild decks can be generated
+// from the whole card pool. Decks of any other format require Sets to list the
+// sets currently legal in that format.
+type DeckGenerator struct {
+	DB    *CardDB
+	Class string
+
+	// Format is the format of each deck. Zero means FormatWild. Formats other
+	// than Wild require Sets.
+	Format Format
+
+	// Sets limits cards to those from the given sets. An empty list allows
+	// cards from every set.
+	Sets []string
+
+	// Size is the number of cards in each deck. Zero means DefaultDeckSize.
+	Size int
+}
+
+// Generate returns a random deck. Returns an error if rng or DB is nil, if the
+// class has no default hero, if Size is negative, if the format isn't Wild and
+// Sets is empty, or if there are not enough eligible cards to fill the deck.
+func (g *DeckGenerator) Generate(rng *rand.Rand) (Deck, error) {
+	if rng == nil {
+		return Deck{}, fmt.Errorf("random deck: nil rng")
+	}
+	if g.DB == nil {
+		return Deck{}, fmt.Errorf("random deck: nil card database")
+	}
+
+	format := g.Format
+	if format == 0 {
+		format = FormatWild
+	}
+	if format != FormatWild && len(g.Sets) == 0 {
+		return Deck{}, fmt.Errorf("random deck: %s decks require Sets", format)
+	}
+
+	hero, ok := DefaultHeroes[g.Class]
+	if !ok {
+		return Deck{}, fmt.Errorf("random deck: unknown class: %s", g.Class)
+	}
+
+	size := g.Size
+	if size < 0 {
+		return Deck{}, fmt.Errorf("random deck: invalid size: %d", size)
+	}
+	if size == 0 {
+		size = DefaultDeckSize
+	}
+
+	// Each eligible card contributes one slot per allowed copy. Shuffling the
+	// slots and taking the first size of them picks cards and counts at once.
+	slots := g.slots()
+	if len(slots) < size {
+		return Deck{}, fmt.Errorf("random deck: only %d eligible cards for %s, need %d", len(slots), g.Class, size)
+	}
+
+	rng.Shuffle(len(slots), func(i, j int) { slots[i], slots[j] = slots[j], slots[i] })
+
+	counts := make(map[uint64]uint64)
+	for _, dbfID := range slots[:size] {
+		counts[dbfID]++
+	}
+
+	deck := Deck{Format: format, Heroes: []uint64{hero}}
+	for _, dbfID := range sortedKeys(counts) {
+		deck.Cards = append(deck.Cards, [2]uint64{dbfID, counts[dbfID]})
+	}
+
+	return deck, nil
+}
+
+// slots returns the DBF ID of every eligible card once per allowed copy, in
+// ascending DBF ID order so generation is deterministic for a given source.
+func (g *DeckGenerator) slots() []uint64 {
+	sets := make(map[string]bool, len(g.Sets))
+	for _, set := range g.Sets {
+		sets[set] = true
+	}
+
+	var slots []uint64
+	for _, card := range g.DB.cards {
+		if !card.Collectible || card.Type == "HERO" {
+			continue
+		}
+		if len(sets) > 0 && !sets[card.Set] {
+			continue
+		}
+		if !card.playableBy(g.Class) {
+			continue
+		}
+
+		slots = append(slots, card.DBFID)
+		if card.Rarity != RarityLegendary {
+			slots = append(slots, card.DBFID)
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return slots
+}
+
+// playableBy reports whether the card may be included in a deck of the class.
+func (c CardInfo) playableBy(class string) bool {
+	if len(c.Classes) > 0 {
+		for _, cardClass := range c.Classes {
+			if cardClass == class {
+				return true
+			}
+		}
+		return false
+	}
+	return c.Class == class || c.Class == "NEUTRAL"
+}
@@ -0,0 +1,97 @@
+package deckstrings_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeckGenerator(t *testing.T) {
+	db := testCardDB(t)
+	generator := DeckGenerator{DB: db, Class: "MAGE", Format: FormatWild, Size: 15}
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		deck, err := generator.Generate(rng)
+		assert.Nil(t, err)
+		assert.Equal(t, FormatWild, deck.Format)
+		assert.Equal(t, []uint64{637}, deck.Heroes)
+
+		total := uint64(0)
+		for _, card := range deck.Cards {
+			info, ok := db.Card(card[0])
+			assert.True(t, ok)
+			assert.True(t, info.Collectible)
+			assert.NotEqual(t, "HERO", info.Type)
+			assert.NotContains(t, []string{"WARRIOR", "SHAMAN", "PALADIN"}, info.Class)
+			if info.Rarity == RarityLegendary {
+				assert.Equal(t, uint64(1), card[1])
+			} else {
+				assert.True(t, card[1] <= 2)
+			}
+			total += card[1]
+		}
+		assert.Equal(t, uint64(15), total)
+	}
+}
+
+func TestDeckGeneratorExhaustsPool(t *testing.T) {
+	// 7 mage cards and 2 neutral cards at 2 copies each, plus 3 legendaries.
+	generator := DeckGenerator{DB: testCardDB(t), Class: "MAGE", Size: 21}
+	deck, err := generator.Generate(rand.New(rand.NewSource(1)))
+	assert.Nil(t, err)
+	assert.Equal(t, 12, len(deck.Cards))
+	assert.Contains(t, deck.Cards, [2]uint64{40408, 1})
+
+	generator.Size = 22
+	_, err = generator.Generate(rand.New(rand.NewSource(1)))
+	assert.NotNil(t, err)
+}
+
+func TestDeckGeneratorSets(t *testing.T) {
+	generator := DeckGenerator{DB: testCardDB(t), Class: "MAGE", Sets: []string{"EXPERT1"}, Size: 6}
+	deck, err := generator.Generate(rand.New(rand.NewSource(1)))
+	assert.Nil(t, err)
+	assert.Equal(t, [][2]uint64{{581, 1}, {749, 1}, {757, 2}, {825, 2}}, deck.Cards)
+}
+
+func TestDeckGeneratorUnknownClass(t *testing.T) {
+	generator := DeckGenerator{DB: testCardDB(t), Class: "BARD"}
+	_, err := generator.Generate(rand.New(rand.NewSource(1)))
+	assert.NotNil(t, err)
+}
+
+func TestDeckGeneratorNegativeSize(t *testing.T) {
+	generator := DeckGenerator{DB: testCardDB(t), Class: "MAGE", Size: -1}
+	_, err := generator.Generate(rand.New(rand.NewSource(1)))
+	assert.EqualError(t, err, "random deck: invalid size: -1")
+}
+
+func TestDeckGeneratorFormat(t *testing.T) {
+	generator := DeckGenerator{DB: testCardDB(t), Class: "MAGE", Size: 6}
+	deck, err := generator.Generate(rand.New(rand.NewSource(1)))
+	assert.Nil(t, err)
+	assert.Equal(t, FormatWild, deck.Format)
+
+	// Without rotation data, only the caller knows which sets are legal.
+	generator.Format = FormatStandard
+	_, err = generator.Generate(rand.New(rand.NewSource(1)))
+	assert.EqualError(t, err, "random deck: Standard decks require Sets")
+
+	generator.Sets = []string{"EXPERT1"}
+	deck, err = generator.Generate(rand.New(rand.NewSource(1)))
+	assert.Nil(t, err)
+	assert.Equal(t, FormatStandard, deck.Format)
+}
+
+func TestDeckGeneratorNil(t *testing.T) {
+	generator := DeckGenerator{DB: testCardDB(t), Class: "MAGE"}
+	_, err := generator.Generate(nil)
+	assert.EqualError(t, err, "random deck: nil rng")
+
+	generator.DB = nil
+	_, err = generator.Generate(rand.New(rand.NewSource(1)))
+	assert.EqualError(t, err, "random deck: nil card database")
+}
@@ -0,0 +1,150 @@
+package deckstrings
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Record is a single deckstring read by a DeckReader along with the result of
+// decoding it. Line is the 1-based line number of the deckstring in the input.
+// Err is set if the deckstring failed to decode, in which case Deck is empty.
+type Record struct {
+	Line       int
+	Deckstring string
+	Deck       Deck
+	Err        error
+}
+
+// DeckReader reads newline-separated deckstrings from an input stream,
+// decoding each as it is read. Decoding errors are reported per record rather
+// than stopping the stream, making DeckReader suitable for large datasets that
+// may contain some invalid lines.
+//
+// Blank lines are skipped, as are comments: lines beginning with '#' and any
+// text following a '#' after a deckstring. Whitespace around deckstrings is
+// ignored. Hearthstone's own deck export format, which lists the deck's name
+// and cards in comment lines, can therefore be read directly.
+//
+// Lines longer than 1 MiB are reported as records with Err set to
+// ErrLineTooLong, and reading resumes at the following line.
+//
+// Usage follows bufio.Scanner:
+//
+//	reader := deckstrings.NewDeckReader(file)
+//	for reader.Next() {
+//		record := reader.Record()
+//		if record.Err != nil {
+//			log.Printf("line %d: %v", record.Line, record.Err)
+//			continue
+//		}
+//		// Use record.Deck.
+//	}
+//	if err := reader.Err(); err != nil {
+//		// Handle read error.
+//	}
+type DeckReader struct {
+	reader *bufio.Reader
+	opts   []DecodeOption
+	logger *slog.Logger
+	line   int
+	record Record
+	err    error
+}
+
+// maxLineLength is the longest line a DeckReader accepts.
+const maxLineLength = 1024 * 1024
+
+// ErrLineTooLong is the Err of a Record read from a line too long to hold a
+// deckstring.
+var ErrLineTooLong = errors.New("line too long")
+
+// NewDeckReader creates a DeckReader reading from reader. Options are passed
+// to Decode for each deckstring. With DecodeLogger, log entries include the
+// line and deckstring of the record being decoded.
+func NewDeckReader(reader io.Reader, opts ...DecodeOption) *DeckReader {
+	return &DeckReader{reader: bufio.NewReader(reader), opts: opts, logger: newDecodeOptions(opts).logger}
+}
+
+// Next advances to the next deckstring, which is then available through
+// Record. It returns false at the end of the input or if reading fails.
+func (r *DeckReader) Next() bool {
+	for r.err == nil {
+		text, tooLong, err := r.readLine()
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			break
+		}
+		r.line++
+
+		if tooLong {
+			r.record = Record{Line: r.line, Err: ErrLineTooLong}
+			return true
+		}
+
+		line := string(text)
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		deckstring := strings.TrimSpace(line)
+		if deckstring == "" {
+			continue
+		}
+
+		opts := r.opts
+		if r.logger != nil {
+			logger := r.logger.With("line", r.line, "deckstring", deckstring)
+			opts = append(opts[:len(opts):len(opts)], DecodeLogger(logger))
+		}
+
+		deck, err := Decode(deckstring, opts...)
+		r.record = Record{Line: r.line, Deckstring: deckstring, Deck: deck, Err: err}
+		return true
+	}
+
+	r.record = Record{}
+	return false
+}
+
+// readLine reads the next line without its line ending. If the line is longer
+// than maxLineLength, the rest of it is discarded and tooLong is true. err is
+// only returned if no line could be read.
+func (r *DeckReader) readLine() (line []byte, tooLong bool, err error) {
+	for read := false; ; read = true {
+		chunk, isPrefix, err := r.reader.ReadLine()
+		if err != nil {
+			if read {
+				return line, tooLong, nil
+			}
+			return nil, false, err
+		}
+
+		if !tooLong {
+			if len(line)+len(chunk) > maxLineLength {
+				line, tooLong = nil, true
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if !isPrefix {
+			return line, tooLong, nil
+		}
+	}
+}
+
+// Record returns the most recent record read by Next.
+func (r *DeckReader) Record() Record {
+	return r.record
+}
+
+// Err returns the first error encountered reading the input, if any. Errors
+// decoding individual deckstrings are reported through Record instead.
+func (r *DeckReader) Err() error {
+	return r.err
+}
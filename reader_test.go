@@ -0,0 +1,115 @@
+package deckstrings_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeckReader(t *testing.T) {
+	input := `# Saved decks
+AAEAAAAAAA==
+
+  AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=   # Hunter
+not a deckstring
+AAEBAgIBAQEAAQcC
+`
+
+	reader := NewDeckReader(strings.NewReader(input))
+
+	var records []Record
+	for reader.Next() {
+		records = append(records, reader.Record())
+	}
+	assert.Nil(t, reader.Err())
+	assert.Len(t, records, 4)
+
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, "AAEAAAAAAA==", records[0].Deckstring)
+	assert.Nil(t, records[0].Err)
+
+	assert.Equal(t, 4, records[1].Line)
+	assert.Equal(t, "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=", records[1].Deckstring)
+	assert.Equal(t, []uint64{31}, records[1].Deck.Heroes)
+
+	assert.Equal(t, 5, records[2].Line)
+	assert.NotNil(t, records[2].Err)
+	assert.Equal(t, Deck{}, records[2].Deck)
+
+	assert.Equal(t, 6, records[3].Line)
+	assert.Nil(t, records[3].Err)
+
+	assert.False(t, reader.Next())
+	assert.Equal(t, Record{}, reader.Record())
+}
+
+func TestDeckReaderOptions(t *testing.T) {
+	reader := NewDeckReader(strings.NewReader("AAEAAAAAAA==\n"), RequireFormat())
+	assert.True(t, reader.Next())
+	assert.NotNil(t, reader.Record().Err)
+}
+
+func TestDeckReaderGameExport(t *testing.T) {
+	input := `### Big Mage
+# Class: Mage
+# Format: Wild
+#
+# 2x (4) Fireball
+#
+AAEBAZICAAAA
+#
+# To use this deck, copy it to your clipboard and create a new deck in Hearthstone
+`
+	reader := NewDeckReader(strings.NewReader(input))
+	assert.True(t, reader.Next())
+	assert.Equal(t, 7, reader.Record().Line)
+	assert.Nil(t, reader.Record().Err)
+	assert.False(t, reader.Next())
+}
+
+func TestDeckReaderLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	reader := NewDeckReader(strings.NewReader("AAEAAAAAAA==\n\n!\n"), DecodeLogger(logger))
+	for reader.Next() {
+	}
+	assert.Nil(t, reader.Err())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Contains(t, lines[0], `level=DEBUG msg="deckstring decode warning" line=1 deckstring="AAEAAAAAAA==" kind=`)
+		assert.Contains(t, lines[2], `level=WARN msg="deckstring decode failed" line=3 deckstring=! error=`)
+	}
+
+	// Nothing is logged at higher levels without failures.
+	buf.Reset()
+	logger = slog.New(slog.NewTextHandler(&buf, nil))
+	_, err := Decode("AAEAAAAAAA==", DecodeLogger(logger))
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestDeckReaderLongLine(t *testing.T) {
+	input := "AAEAAAAAAA==\n" + strings.Repeat("A", 2*1024*1024) + "\nAAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=\r\n" + strings.Repeat("B", 1024*1024+1)
+
+	reader := NewDeckReader(strings.NewReader(input))
+
+	var records []Record
+	for reader.Next() {
+		records = append(records, reader.Record())
+	}
+	assert.Nil(t, reader.Err())
+	assert.Len(t, records, 4)
+
+	assert.Nil(t, records[0].Err)
+	assert.Equal(t, Record{Line: 2, Err: ErrLineTooLong}, records[1])
+	assert.Equal(t, 3, records[2].Line)
+	assert.Nil(t, records[2].Err)
+	assert.Equal(t, []uint64{31}, records[2].Deck.Heroes)
+	assert.Equal(t, Record{Line: 4, Err: ErrLineTooLong}, records[3])
+}
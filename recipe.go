@@ -0,0 +1,103 @@
+package deckstrings
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Recipe is a known deck list, such as an official deck recipe or a Whizbang
+// deck.
+type Recipe struct {
+	Name string
+	Deck Deck
+}
+
+// RecipeMatch is a recipe along with how closely a deck matches it.
+//
+// Similarity is the Similarity of the deck's cards to the recipe's cards. Diff
+// lists the changes from the recipe to the deck. Exact reports whether the deck
+// has exactly the recipe's cards; heroes and formats are not compared since
+// recipes are often played with alternate heroes or in other formats.
+type RecipeMatch struct {
+	Recipe     Recipe
+	Similarity float64
+	Diff       DeckDiff
+	Exact      bool
+}
+
+// RecipeMatcher identifies which of a set of recipes a deck is or is closest
+// to.
+//
+// MinSimilarity is the minimum similarity a deck must have to a recipe for it
+// to be considered a match.
+type RecipeMatcher struct {
+	Recipes       []Recipe
+	MinSimilarity float64
+}
+
+// Match returns the recipe most similar to the deck. It returns false if no
+// recipe has at least MinSimilarity or no cards in common with the deck. Ties
+// are broken by recipe order.
+func (m *RecipeMatcher) Match(deck Deck) (RecipeMatch, bool) {
+	matches := m.Matches(deck)
+	if len(matches) == 0 {
+		return RecipeMatch{}, false
+	}
+	return matches[0], true
+}
+
+// Matches returns every recipe with at least MinSimilarity and some cards in
+// common with the deck, ordered by similarity descending.
+func (m *RecipeMatcher) Matches(deck Deck) []RecipeMatch {
+	var matches []RecipeMatch
+	for _, recipe := range m.Recipes {
+		similarity := Similarity(recipe.Deck, deck)
+		if similarity == 0 || similarity < m.MinSimilarity {
+			continue
+		}
+
+		diff := Diff(recipe.Deck, deck)
+		matches = append(matches, RecipeMatch{
+			Recipe:     recipe,
+			Similarity: similarity,
+			Diff:       diff,
+			Exact:      len(diff.Removed) == 0 && len(diff.Added) == 0 && len(diff.Changed) == 0,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Similarity > matches[j].Similarity })
+	return matches
+}
+
+// LoadRecipes reads recipes from JSON: an array of objects with a "name" and a
+// "deckstring", e.g.
+//
+//	[{"name": "Whizbang's Freeze Mage", "deckstring": "AAECAf0EAA..."}]
+func LoadRecipes(reader io.Reader) (recipes []Recipe, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "recipes load")
+		}
+	}()
+
+	var entries []struct {
+		Name       string `json:"name"`
+		Deckstring string `json:"deckstring"`
+	}
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	recipes = make([]Recipe, 0, len(entries))
+	for _, entry := range entries {
+		deck, err := Decode(entry.Deckstring)
+		if err != nil {
+			return nil, errors.Wrapf(err, "recipe %q", entry.Name)
+		}
+		recipes = append(recipes, Recipe{Name: entry.Name, Deck: deck})
+	}
+	return recipes, nil
+}
@@ -0,0 +1,70 @@
+package deckstrings_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecipes(t *testing.T) []Recipe {
+	warrior := Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{401, 2}, {757, 2}}}
+	text := fmt.Sprintf(`[
+		{"name": "Freeze Mage", "deckstring": %q},
+		{"name": "Axe Warrior", "deckstring": %q}
+	]`, MustEncode(testMageDeck()), MustEncode(warrior))
+
+	recipes, err := LoadRecipes(strings.NewReader(text))
+	assert.Nil(t, err)
+	return recipes
+}
+
+func TestLoadRecipes(t *testing.T) {
+	recipes := testRecipes(t)
+	assert.Len(t, recipes, 2)
+	assert.Equal(t, "Freeze Mage", recipes[0].Name)
+	assert.True(t, Equal(testMageDeck(), recipes[0].Deck))
+
+	_, err := LoadRecipes(strings.NewReader(`[{"name": "Broken", "deckstring": "!"}]`))
+	assert.Contains(t, fmt.Sprint(err), `recipes load: recipe "Broken": deckstring decode`)
+
+	_, err = LoadRecipes(strings.NewReader(`{`))
+	assert.NotNil(t, err)
+}
+
+func TestRecipeMatcher(t *testing.T) {
+	matcher := &RecipeMatcher{Recipes: testRecipes(t), MinSimilarity: 0.5}
+
+	// Alternate heroes and formats still match exactly.
+	deck := testMageDeck()
+	deck.Format = FormatStandard
+	deck.Heroes = []uint64{2829}
+	match, ok := matcher.Match(deck)
+	assert.True(t, ok)
+	assert.Equal(t, "Freeze Mage", match.Recipe.Name)
+	assert.Equal(t, 1.0, match.Similarity)
+	assert.True(t, match.Exact)
+
+	// Swapping a Fireball for a Fiery War Axe is close to the recipe.
+	mutated := Deck{Format: FormatWild, Heroes: []uint64{637}, Cards: append([][2]uint64{{401, 1}}, testMageDeck().Cards...)}
+	mutated.Cards[2][1] = 1
+	match, ok = matcher.Match(mutated)
+	assert.True(t, ok)
+	assert.Equal(t, "Freeze Mage", match.Recipe.Name)
+	assert.False(t, match.Exact)
+	assert.Equal(t, [][2]uint64{{401, 1}}, match.Diff.Added)
+	assert.Equal(t, []CardDelta{{DBFID: 315, From: 2, To: 1}}, match.Diff.Changed)
+
+	matches := matcher.Matches(mutated)
+	assert.Len(t, matches, 1)
+	matcher.MinSimilarity = 0
+	matches = matcher.Matches(mutated)
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "Axe Warrior", matches[1].Recipe.Name)
+	}
+
+	_, ok = matcher.Match(Deck{Cards: [][2]uint64{{1, 1}}})
+	assert.False(t, ok)
+}
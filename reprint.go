@@ -0,0 +1,86 @@
+package deckstrings
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Reprints lists groups of functionally identical cards with different DBF
+// IDs, such as cards reprinted into a yearly Core set. Each group lists the
+// DBF IDs of one card's printings from the original to the latest.
+type Reprints [][]uint64
+
+// LoadReprints reads reprint groups from JSON: an array of arrays of DBF IDs,
+// each ordered from the original printing to the latest, e.g.
+//
+//	[[315, 64678], [581, 69545]]
+func LoadReprints(reader io.Reader) (Reprints, error) {
+	var reprints Reprints
+	if err := json.NewDecoder(reader).Decode(&reprints); err != nil {
+		return nil, errors.Wrap(err, "reprints load")
+	}
+	return reprints, nil
+}
+
+// ReprintsFromCardDB derives reprint groups from the card IDs in db, which
+// give Core set reprints the card ID of the original with a "CORE_" prefix,
+// e.g. "CORE_CS2_029" for "CS2_029". Groups are ordered by DBF ID.
+func ReprintsFromCardDB(db *CardDB) Reprints {
+	var reprints Reprints
+	for id, dbfID := range db.byID {
+		if !strings.HasPrefix(id, "CORE_") {
+			continue
+		}
+		if original, ok := db.byID[strings.TrimPrefix(id, "CORE_")]; ok {
+			reprints = append(reprints, []uint64{min(original, dbfID), max(original, dbfID)})
+		}
+	}
+
+	sort.Slice(reprints, func(i, j int) bool { return reprints[i][0] < reprints[j][0] })
+	return reprints
+}
+
+// Remap returns a copy of the deck with each hero and card replaced by the
+// printing chosen by pick from the card's reprint group. Cards that are not
+// reprinted are kept as is. Cards that map to the same printing are merged, and
+// the result is canonical.
+func (r Reprints) Remap(deck Deck, pick func(printings []uint64) uint64) Deck {
+	groups := make(map[uint64][]uint64)
+	for _, group := range r {
+		for _, dbfID := range group {
+			groups[dbfID] = group
+		}
+	}
+
+	remap := func(dbfID uint64) uint64 {
+		if group, ok := groups[dbfID]; ok {
+			return pick(group)
+		}
+		return dbfID
+	}
+
+	remapped := Deck{Format: deck.Format, Version: deck.Version}
+	for _, hero := range deck.Heroes {
+		remapped.Heroes = append(remapped.Heroes, remap(hero))
+	}
+	for _, card := range deck.Cards {
+		remapped.Cards = append(remapped.Cards, [2]uint64{remap(card[0]), card[1]})
+	}
+	return Canonicalize(remapped)
+}
+
+// ToOriginal returns a copy of the deck using the original printing of every
+// reprinted card, for comparing decks built from different printings.
+func (r Reprints) ToOriginal(deck Deck) Deck {
+	return r.Remap(deck, func(printings []uint64) uint64 { return printings[0] })
+}
+
+// ToLatest returns a copy of the deck using the latest printing of every
+// reprinted card, for replaying historical decks against current data.
+func (r Reprints) ToLatest(deck Deck) Deck {
+	return r.Remap(deck, func(printings []uint64) uint64 { return printings[len(printings)-1] })
+}
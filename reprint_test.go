@@ -0,0 +1,51 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadReprints(t *testing.T) {
+	reprints, err := LoadReprints(strings.NewReader(`[[315, 64678], [581, 69545]]`))
+	assert.Nil(t, err)
+	assert.Equal(t, Reprints{{315, 64678}, {581, 69545}}, reprints)
+
+	_, err = LoadReprints(strings.NewReader(`[["x"]]`))
+	assert.NotNil(t, err)
+}
+
+func TestReprintsFromCardDB(t *testing.T) {
+	assert.Equal(t, Reprints{{315, 64678}}, ReprintsFromCardDB(testCardDB(t)))
+}
+
+func TestReprintsRemap(t *testing.T) {
+	reprints := Reprints{{315, 64678}, {637, 2829, 57761}}
+	deck := Deck{
+		Format:  FormatStandard,
+		Heroes:  []uint64{637},
+		Cards:   [][2]uint64{{315, 1}, {581, 1}, {64678, 1}},
+		Version: 2,
+	}
+
+	assert.Equal(t, Deck{
+		Format:  FormatStandard,
+		Heroes:  []uint64{637},
+		Cards:   [][2]uint64{{315, 2}, {581, 1}},
+		Version: 2,
+	}, reprints.ToOriginal(deck))
+
+	assert.Equal(t, Deck{
+		Format:  FormatStandard,
+		Heroes:  []uint64{57761},
+		Cards:   [][2]uint64{{581, 1}, {64678, 2}},
+		Version: 2,
+	}, reprints.ToLatest(deck))
+
+	// The deck is not modified.
+	assert.Equal(t, [][2]uint64{{315, 1}, {581, 1}, {64678, 1}}, deck.Cards)
+
+	assert.True(t, Equal(Canonicalize(testMageDeck()), Reprints(nil).ToOriginal(testMageDeck())))
+}
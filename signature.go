@@ -0,0 +1,98 @@
+package deckstrings
+
+import (
+	"math"
+	"sort"
+)
+
+// Corpus is a collection of decks used as background when computing the
+// signature of a group of decks. Only card inclusion counts are retained, so a
+// Corpus stays small regardless of the number of decks added.
+type Corpus struct {
+	decks     int
+	inclusion map[uint64]int
+}
+
+// NewCorpus creates a corpus from the given decks.
+func NewCorpus(decks []Deck) *Corpus {
+	corpus := &Corpus{inclusion: make(map[uint64]int)}
+	for _, deck := range decks {
+		corpus.Add(deck)
+	}
+	return corpus
+}
+
+// Add adds a deck to the corpus.
+func (c *Corpus) Add(deck Deck) {
+	c.decks++
+	for dbfID := range cardCountsByID(deck.Cards) {
+		c.inclusion[dbfID]++
+	}
+}
+
+// Len returns the number of decks in the corpus.
+func (c *Corpus) Len() int {
+	return c.decks
+}
+
+// Signature weights cards by how characteristic they are of a group of decks.
+// A Signature can be used as the weights of an Archetype.
+type Signature map[uint64]float64
+
+// Top returns the DBF IDs of the n most heavily weighted cards, ordered by
+// weight descending and then by DBF ID ascending. A negative n is treated as 0.
+func (s Signature) Top(n int) []uint64 {
+	dbfIDs := make([]uint64, 0, len(s))
+	for dbfID := range s {
+		dbfIDs = append(dbfIDs, dbfID)
+	}
+
+	sort.Slice(dbfIDs, func(i, j int) bool {
+		if s[dbfIDs[i]] != s[dbfIDs[j]] {
+			return s[dbfIDs[i]] > s[dbfIDs[j]]
+		}
+		return dbfIDs[i] < dbfIDs[j]
+	})
+
+	if n < 0 {
+		n = 0
+	}
+	if n < len(dbfIDs) {
+		dbfIDs = dbfIDs[:n]
+	}
+	return dbfIDs
+}
+
+// SignatureOf computes the signature of a group of decks, such as a cluster of
+// decks of the same archetype, against the corpus using tf-idf weighting.
+//
+// A card's term frequency is the fraction of decks in the group containing it.
+// Its inverse document frequency is ln((1 + N) / (1 + n)), where N is the
+// number of decks in the corpus and n is the number of corpus decks containing
+// the card. Cards played in nearly every corpus deck are thus weighted close to
+// zero, while cards specific to the group are weighted most heavily. The
+// corpus would typically include the group's decks. Cards with a weight of
+// zero or less are omitted.
+func (c *Corpus) SignatureOf(decks []Deck) Signature {
+	signature := make(Signature)
+	if len(decks) == 0 {
+		return signature
+	}
+
+	inclusion := make(map[uint64]int)
+	for _, deck := range decks {
+		for dbfID := range cardCountsByID(deck.Cards) {
+			inclusion[dbfID]++
+		}
+	}
+
+	for dbfID, n := range inclusion {
+		tf := float64(n) / float64(len(decks))
+		idf := math.Log(float64(1+c.decks) / float64(1+c.inclusion[dbfID]))
+		if weight := tf * idf; weight > 0 {
+			signature[dbfID] = weight
+		}
+	}
+
+	return signature
+}
@@ -0,0 +1,50 @@
+package deckstrings_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureOf(t *testing.T) {
+	freeze := []Deck{
+		{Cards: [][2]uint64{{1, 2}, {10, 2}, {11, 2}}},
+		{Cards: [][2]uint64{{1, 2}, {10, 2}, {12, 1}}},
+	}
+	aggro := []Deck{
+		{Cards: [][2]uint64{{1, 2}, {20, 2}}},
+		{Cards: [][2]uint64{{1, 2}, {20, 2}, {21, 1}}},
+	}
+
+	corpus := NewCorpus(append(append([]Deck{}, freeze...), aggro...))
+	assert.Equal(t, 4, corpus.Len())
+
+	signature := corpus.SignatureOf(freeze)
+
+	// Card 1 is in every deck and so is not distinguishing.
+	_, ok := signature[1]
+	assert.False(t, ok)
+
+	assert.InDelta(t, math.Log(5.0/3.0), signature[10], 1e-9)
+	assert.InDelta(t, 0.5*math.Log(5.0/2.0), signature[11], 1e-9)
+	assert.Equal(t, []uint64{10, 11, 12}, signature.Top(5))
+	assert.Equal(t, []uint64{10}, signature.Top(1))
+	assert.Equal(t, []uint64{}, signature.Top(-1))
+
+	classifier := &Classifier{Archetypes: []Archetype{
+		{Name: "Freeze", Weights: signature},
+		{Name: "Aggro", Weights: corpus.SignatureOf(aggro)},
+	}}
+
+	match, ok := classifier.Classify(Deck{Cards: [][2]uint64{{1, 2}, {20, 1}}})
+	assert.True(t, ok)
+	assert.Equal(t, "Aggro", match.Archetype.Name)
+}
+
+func TestSignatureOfEmpty(t *testing.T) {
+	corpus := NewCorpus(nil)
+	assert.Empty(t, corpus.SignatureOf(nil))
+	assert.Empty(t, corpus.SignatureOf([]Deck{{Cards: [][2]uint64{{1, 1}}}}))
+}
@@ -0,0 +1,87 @@
+package deckstrings
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSignatureMismatch is the cause of errors returned when a signed
+// deckstring's signature does not match, meaning it was tampered with or
+// signed with a different key. Use errors.Cause to check for it.
+var ErrSignatureMismatch = errors.New("signature mismatch")
+
+// EncodeSigned encodes a deck like Encode and appends an HMAC-SHA256 signature
+// computed with key, so services that issue deck codes can verify they
+// haven't been modified. Use DecodeSigned with the same key to decode the
+// result. The key should be random and at least 32 bytes long.
+//
+// The signature is appended after a "." as 43 unpadded base64 characters.
+// Signed deckstrings can't be imported into the game; use VerifySigned to
+// recover the plain deckstring. The deck itself is not encrypted.
+func EncodeSigned(deck Deck, key []byte, opts ...EncodeOption) (string, error) {
+	if len(key) == 0 {
+		return "", errors.New("deckstring sign: empty key")
+	}
+
+	deckstring, err := Encode(deck, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return deckstring + "." + base64.RawStdEncoding.EncodeToString(sign(deckstring, key)), nil
+}
+
+// DecodeSigned verifies the signature of a deckstring created by EncodeSigned
+// and decodes the deckstring like Decode. If the signature does not match,
+// errors.Cause of the returned error is ErrSignatureMismatch.
+func DecodeSigned(signed string, key []byte, opts ...DecodeOption) (Deck, error) {
+	deckstring, err := VerifySigned(signed, key)
+	if err != nil {
+		return Deck{}, err
+	}
+	return Decode(deckstring, opts...)
+}
+
+// VerifySigned verifies the signature of a deckstring created by EncodeSigned
+// and returns the deckstring with the signature removed. The deckstring itself
+// is not decoded. If the signature does not match, errors.Cause of the
+// returned error is ErrSignatureMismatch.
+func VerifySigned(signed string, key []byte) (deckstring string, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "deckstring verify")
+		}
+	}()
+
+	if len(key) == 0 {
+		return "", fmt.Errorf("empty key")
+	}
+
+	i := strings.LastIndexByte(signed, '.')
+	if i < 0 {
+		return "", fmt.Errorf("missing signature")
+	}
+
+	deckstring, suffix := signed[:i], signed[i+1:]
+	signature, err := base64.RawStdEncoding.DecodeString(suffix)
+	if err != nil || len(signature) != sha256.Size {
+		return "", fmt.Errorf("invalid signature: %q", suffix)
+	}
+
+	if !hmac.Equal(signature, sign(deckstring, key)) {
+		return "", ErrSignatureMismatch
+	}
+
+	return deckstring, nil
+}
+
+func sign(deckstring string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(deckstring))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,59 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncodeDecodeSigned(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+	deck := MustDecode(deckstring)
+
+	signed, err := EncodeSigned(deck, testKey)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(signed, deckstring+"."))
+	assert.Len(t, signed, len(deckstring)+1+43)
+
+	decoded, err := DecodeSigned(signed, testKey)
+	assert.Nil(t, err)
+	assert.Equal(t, deck, decoded)
+
+	verified, err := VerifySigned(signed, testKey)
+	assert.Nil(t, err)
+	assert.Equal(t, deckstring, verified)
+}
+
+func TestDecodeSignedMismatch(t *testing.T) {
+	signed, err := EncodeSigned(MustDecode("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="), testKey)
+	assert.Nil(t, err)
+
+	_, err = DecodeSigned(signed, []byte("another key"))
+	assert.EqualError(t, err, "deckstring verify: signature mismatch")
+	assert.Equal(t, ErrSignatureMismatch, errors.Cause(err))
+
+	// Swap in a different deck, keeping the signature.
+	i := strings.LastIndexByte(signed, '.')
+	tampered := MustEncode(MustDecode("AAEBAQcAAAQBAwIDAwMEAw==")) + signed[i:]
+	_, err = DecodeSigned(tampered, testKey)
+	assert.Equal(t, ErrSignatureMismatch, errors.Cause(err))
+}
+
+func TestDecodeSignedInvalid(t *testing.T) {
+	_, err := DecodeSigned("AAEBAQcAAAQBAwIDAwMEAw==", testKey)
+	assert.EqualError(t, err, "deckstring verify: missing signature")
+
+	_, err = DecodeSigned("AAEBAQcAAAQBAwIDAwMEAw==.abc", testKey)
+	assert.EqualError(t, err, `deckstring verify: invalid signature: "abc"`)
+
+	_, err = DecodeSigned("AAEBAQcAAAQBAwIDAwMEAw==.abc", nil)
+	assert.EqualError(t, err, "deckstring verify: empty key")
+
+	_, err = EncodeSigned(Deck{}, nil)
+	assert.EqualError(t, err, "deckstring sign: empty key")
+}
@@ -0,0 +1,100 @@
+package deckstrings
+
+import "math"
+
+// SimilarityOption configures how Similarity compares decks.
+type SimilarityOption func(*similarityOptions)
+
+type similarityOptions struct {
+	cosine       bool
+	ignoreCounts bool
+}
+
+// Cosine compares decks using cosine similarity over card count vectors
+// rather than Jaccard similarity.
+func Cosine() SimilarityOption {
+	return func(o *similarityOptions) {
+		o.cosine = true
+	}
+}
+
+// IgnoreCounts compares decks by which cards they contain, treating every
+// card as if it had a count of 1.
+func IgnoreCounts() SimilarityOption {
+	return func(o *similarityOptions) {
+		o.ignoreCounts = true
+	}
+}
+
+// Similarity scores how alike two decks are by their cards, from 0 (no cards
+// in common) to 1 (identical cards). Formats and heroes are not compared.
+//
+// By default, Similarity computes the weighted Jaccard similarity over card
+// multisets: the sum of each card's smaller count divided by the sum of each
+// card's larger count. Use Cosine for cosine similarity and IgnoreCounts to
+// disregard card counts. Two decks without cards are identical.
+func Similarity(a, b Deck, opts ...SimilarityOption) float64 {
+	options := &similarityOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	countsA, countsB := cardCountsByID(a.Cards), cardCountsByID(b.Cards)
+	if options.ignoreCounts {
+		for dbfID := range countsA {
+			countsA[dbfID] = 1
+		}
+		for dbfID := range countsB {
+			countsB[dbfID] = 1
+		}
+	}
+
+	if len(countsA) == 0 && len(countsB) == 0 {
+		return 1
+	}
+
+	if options.cosine {
+		return cosineSimilarity(countsA, countsB)
+	}
+	return jaccardSimilarity(countsA, countsB)
+}
+
+func jaccardSimilarity(a, b map[uint64]uint64) float64 {
+	var intersection, union uint64
+	for dbfID, countA := range a {
+		countB := b[dbfID]
+		if countA < countB {
+			intersection += countA
+			union += countB
+		} else {
+			intersection += countB
+			union += countA
+		}
+	}
+	for dbfID, countB := range b {
+		if _, ok := a[dbfID]; !ok {
+			union += countB
+		}
+	}
+
+	if union == 0 {
+		return 1
+	}
+	return float64(intersection) / float64(union)
+}
+
+func cosineSimilarity(a, b map[uint64]uint64) float64 {
+	var dot, normA, normB float64
+	for dbfID, countA := range a {
+		dot += float64(countA) * float64(b[dbfID])
+		normA += float64(countA) * float64(countA)
+	}
+	for _, countB := range b {
+		normB += float64(countB) * float64(countB)
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
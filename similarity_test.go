@@ -0,0 +1,47 @@
+package deckstrings_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarityJaccard(t *testing.T) {
+	a := Deck{Cards: [][2]uint64{{1, 2}, {2, 2}, {3, 1}}}
+	b := Deck{Cards: [][2]uint64{{1, 2}, {2, 1}, {4, 1}}}
+
+	// min: 2 + 1 = 3, max: 2 + 2 + 1 + 1 = 6.
+	assert.InDelta(t, 0.5, Similarity(a, b), 1e-9)
+
+	// Shared: 1, 2; all: 1, 2, 3, 4.
+	assert.InDelta(t, 0.5, Similarity(a, b, IgnoreCounts()), 1e-9)
+
+	assert.InDelta(t, 1.0, Similarity(a, a), 1e-9)
+	assert.InDelta(t, 0.0, Similarity(a, Deck{Cards: [][2]uint64{{9, 1}}}), 1e-9)
+}
+
+func TestSimilarityCosine(t *testing.T) {
+	a := Deck{Cards: [][2]uint64{{1, 2}, {2, 2}}}
+	b := Deck{Cards: [][2]uint64{{1, 2}, {3, 2}}}
+
+	assert.InDelta(t, 0.5, Similarity(a, b, Cosine()), 1e-9)
+	assert.InDelta(t, 1.0, Similarity(a, a, Cosine()), 1e-9)
+
+	c := Deck{Cards: [][2]uint64{{1, 2}, {2, 1}}}
+	assert.InDelta(t, 1.0, Similarity(a, c, Cosine(), IgnoreCounts()), 1e-9)
+	assert.InDelta(t, 6/math.Sqrt(40), Similarity(a, c, Cosine()), 1e-9)
+}
+
+func TestSimilarityEmpty(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity(Deck{}, Deck{}))
+	assert.Equal(t, 0.0, Similarity(Deck{}, Deck{Cards: [][2]uint64{{1, 1}}}))
+	assert.Equal(t, 0.0, Similarity(Deck{}, Deck{Cards: [][2]uint64{{1, 1}}}, Cosine()))
+}
+
+func TestSimilarityDuplicates(t *testing.T) {
+	a := Deck{Cards: [][2]uint64{{1, 1}, {1, 1}}}
+	b := Deck{Cards: [][2]uint64{{1, 2}}}
+	assert.Equal(t, 1.0, Similarity(a, b))
+}
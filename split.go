@@ -0,0 +1,43 @@
+package deckstrings
+
+// ClassSplit is a deck's cards split into class and neutral cards, each in
+// game order.
+//
+// Class lists every class card, including multi-class cards such as Kazakus.
+// ByClass lists the class cards of each class, with multi-class cards listed
+// under every class that can play them, e.g. for Duels or Twist decks with
+// cards from several classes.
+type ClassSplit struct {
+	Class   []DeckCard
+	Neutral []DeckCard
+	ByClass map[string][]DeckCard
+}
+
+// SplitByClass splits the cards in a deck into class cards and neutral cards.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func SplitByClass(deck Deck, db *CardDB) (ClassSplit, error) {
+	cards, err := Enrich(deck, db)
+	if err != nil {
+		return ClassSplit{}, err
+	}
+
+	split := ClassSplit{ByClass: make(map[string][]DeckCard)}
+	for _, card := range cards {
+		classes := card.Classes
+		if len(classes) == 0 && card.Class != "" && card.Class != "NEUTRAL" {
+			classes = []string{card.Class}
+		}
+
+		if len(classes) == 0 {
+			split.Neutral = append(split.Neutral, card)
+			continue
+		}
+
+		split.Class = append(split.Class, card)
+		for _, class := range classes {
+			split.ByClass[class] = append(split.ByClass[class], card)
+		}
+	}
+	return split, nil
+}
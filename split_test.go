@@ -0,0 +1,39 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dbfIDs(cards []DeckCard) []uint64 {
+	ids := make([]uint64, len(cards))
+	for i, card := range cards {
+		ids[i] = card.DBFID
+	}
+	return ids
+}
+
+func TestSplitByClass(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{315, 2}, {401, 1}, {581, 1}, {757, 2}, {40408, 1}}}
+
+	split, err := SplitByClass(deck, testCardDB(t))
+	require.Nil(t, err)
+
+	assert.Equal(t, []uint64{401, 315, 40408}, dbfIDs(split.Class))
+	assert.Equal(t, []uint64{757, 581}, dbfIDs(split.Neutral))
+	assert.Equal(t, uint64(2), split.Neutral[0].Count)
+
+	assert.Len(t, split.ByClass, 4)
+	assert.Equal(t, []uint64{315, 40408}, dbfIDs(split.ByClass["MAGE"]))
+	assert.Equal(t, []uint64{401}, dbfIDs(split.ByClass["WARRIOR"]))
+	assert.Equal(t, []uint64{40408}, dbfIDs(split.ByClass["PRIEST"]))
+	assert.Equal(t, []uint64{40408}, dbfIDs(split.ByClass["WARLOCK"]))
+}
+
+func TestSplitByClassUnknownCard(t *testing.T) {
+	_, err := SplitByClass(Deck{Cards: [][2]uint64{{999999, 1}}}, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
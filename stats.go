@@ -0,0 +1,269 @@
+package deckstrings
+
+import "fmt"
+
+// Curve is a mana curve: Curve[i] is the number of cards in a deck costing i
+// mana. The last bucket also counts every card costing more.
+type Curve []uint64
+
+// ManaCurve counts the cards in a deck by mana cost. Cards costing top or more
+// are counted in the last bucket, so the curve has top+1 buckets. Use a top of
+// 7 for the conventional 0 through 6 and 7+ buckets.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func ManaCurve(deck Deck, db *CardDB, top int) (Curve, error) {
+	if top < 0 {
+		return nil, fmt.Errorf("invalid mana curve top bucket: %d", top)
+	}
+
+	curve := make(Curve, top+1)
+	err := tally(deck, db, func(card CardInfo, count uint64) {
+		cost := card.Cost
+		if cost > top {
+			cost = top
+		} else if cost < 0 {
+			cost = 0
+		}
+
+		curve[cost] += count
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return curve, nil
+}
+
+// DustTable maps card rarities to a crafting cost in arcane dust. Rarities
+// missing from the table, such as RarityFree, cost nothing.
+type DustTable map[Rarity]uint64
+
+var (
+	// CraftingCost is the arcane dust needed to craft a regular card.
+	CraftingCost = DustTable{
+		RarityCommon:    40,
+		RarityRare:      100,
+		RarityEpic:      400,
+		RarityLegendary: 1600,
+	}
+
+	// GoldenCraftingCost is the arcane dust needed to craft a golden card.
+	GoldenCraftingCost = DustTable{
+		RarityCommon:    400,
+		RarityRare:      800,
+		RarityEpic:      1600,
+		RarityLegendary: 3200,
+	}
+)
+
+// Scale returns a copy of the table with every cost multiplied by factor. It
+// can be used to derive tables for premium finishes, e.g. signature cards.
+func (t DustTable) Scale(factor uint64) DustTable {
+	scaled := make(DustTable, len(t))
+	for rarity, cost := range t {
+		scaled[rarity] = cost * factor
+	}
+	return scaled
+}
+
+// DustCost computes the arcane dust needed to craft every card in a deck
+// using the costs in table, typically CraftingCost or GoldenCraftingCost.
+// Cards from the Core set are free to all players and cannot be crafted, so
+// they cost nothing.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func DustCost(deck Deck, db *CardDB, table DustTable) (uint64, error) {
+	total := uint64(0)
+	err := tally(deck, db, func(card CardInfo, count uint64) {
+		total += count * craftingCost(card, table)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func craftingCost(card CardInfo, table DustTable) uint64 {
+	if card.Set == "CORE" {
+		return 0
+	}
+	return table[card.Rarity]
+}
+
+// Breakdown counts cards in a deck by category, e.g. by set or card type.
+// Counts include every copy of a card.
+type Breakdown map[string]uint64
+
+// RarityBreakdown counts the cards in a deck by rarity.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func RarityBreakdown(deck Deck, db *CardDB) (map[Rarity]uint64, error) {
+	rarities := make(map[Rarity]uint64)
+	err := tally(deck, db, func(card CardInfo, count uint64) {
+		rarities[card.Rarity] += count
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rarities, nil
+}
+
+// SetBreakdown counts the cards in a deck by card set, e.g. "EXPERT1".
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func SetBreakdown(deck Deck, db *CardDB) (Breakdown, error) {
+	return breakdown(deck, db, func(card CardInfo) []string {
+		return []string{card.Set}
+	})
+}
+
+// TypeBreakdown counts the cards in a deck by card type, e.g. "MINION",
+// "SPELL", "WEAPON", or "LOCATION".
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func TypeBreakdown(deck Deck, db *CardDB) (Breakdown, error) {
+	return breakdown(deck, db, func(card CardInfo) []string {
+		return []string{card.Type}
+	})
+}
+
+// SpellSchoolBreakdown counts the spells in a deck by spell school, e.g.
+// "FIRE". Spells without a school are not counted.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func SpellSchoolBreakdown(deck Deck, db *CardDB) (Breakdown, error) {
+	return breakdown(deck, db, func(card CardInfo) []string {
+		if card.SpellSchool == "" {
+			return nil
+		}
+		return []string{card.SpellSchool}
+	})
+}
+
+// TribeBreakdown counts the minions in a deck by minion type, e.g. "DRAGON".
+// Minions with multiple types are counted under each type. Minions without a
+// type are not counted.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func TribeBreakdown(deck Deck, db *CardDB) (Breakdown, error) {
+	return breakdown(deck, db, func(card CardInfo) []string {
+		return card.Races
+	})
+}
+
+// keywords is the set of HearthstoneJSON mechanics that are keywords shown on
+// cards. It is only read after initialization, so it is safe for concurrent
+// use. See IsKeyword.
+var keywords = map[string]bool{
+	"ADAPT":         true,
+	"BATTLECRY":     true,
+	"CHARGE":        true,
+	"CHOOSE_ONE":    true,
+	"COLOSSAL":      true,
+	"COMBO":         true,
+	"CORRUPT":       true,
+	"COUNTER":       true,
+	"DEATHRATTLE":   true,
+	"DISCOVER":      true,
+	"DIVINE_SHIELD": true,
+	"DORMANT":       true,
+	"DREDGE":        true,
+	"ECHO":          true,
+	"ELUSIVE":       true,
+	"ENRAGED":       true,
+	"EXCAVATE":      true,
+	"FINALE":        true,
+	"FORGE":         true,
+	"FREEZE":        true,
+	"FRENZY":        true,
+	"HONORABLEKILL": true,
+	"IMMUNE":        true,
+	"INFUSE":        true,
+	"INSPIRE":       true,
+	"INVOKE":        true,
+	"JADE_GOLEM":    true,
+	"LACKEY":        true,
+	"LIFESTEAL":     true,
+	"MAGNETIC":      true,
+	"MANATHIRST":    true,
+	"MEGA_WINDFURY": true,
+	"MINIATURIZE":   true,
+	"OUTCAST":       true,
+	"OVERHEAL":      true,
+	"OVERKILL":      true,
+	"OVERLOAD":      true,
+	"POISONOUS":     true,
+	"QUEST":         true,
+	"QUESTLINE":     true,
+	"QUICKDRAW":     true,
+	"REBORN":        true,
+	"RECRUIT":       true,
+	"RUSH":          true,
+	"SECRET":        true,
+	"SIDEQUEST":     true,
+	"SILENCE":       true,
+	"SPELLBURST":    true,
+	"SPELLPOWER":    true,
+	"START_OF_GAME": true,
+	"STEALTH":       true,
+	"TAUNT":         true,
+	"TITAN":         true,
+	"TRADEABLE":     true,
+	"TWINSPELL":     true,
+	"VENOMOUS":      true,
+	"WINDFURY":      true,
+}
+
+// IsKeyword reports whether a HearthstoneJSON mechanic is a keyword shown on
+// cards, e.g. "TAUNT" or "DISCOVER". Other mechanics, such as "AURA" or
+// "TRIGGER_VISUAL", are internal to the game client. The set of keywords grows
+// as new keywords are released.
+func IsKeyword(mechanic string) bool {
+	return keywords[mechanic]
+}
+
+// KeywordBreakdown counts the cards in a deck having each keyword, e.g.
+// "TAUNT", "RUSH", or "DISCOVER". Cards with multiple keywords are counted
+// under each keyword. Mechanics that aren't keywords, as reported by
+// IsKeyword, are ignored.
+//
+// Returns an UnknownCardError if a card in the deck is not in db.
+func KeywordBreakdown(deck Deck, db *CardDB) (Breakdown, error) {
+	return breakdown(deck, db, func(card CardInfo) []string {
+		var keywords []string
+		for _, mechanic := range card.Mechanics {
+			if IsKeyword(mechanic) {
+				keywords = append(keywords, mechanic)
+			}
+		}
+		return keywords
+	})
+}
+
+// breakdown counts cards under every key returned by keys. Cards with no keys
+// are not counted.
+func breakdown(deck Deck, db *CardDB, keys func(CardInfo) []string) (Breakdown, error) {
+	counts := make(Breakdown)
+	err := tally(deck, db, func(card CardInfo, count uint64) {
+		for _, key := range keys(card) {
+			counts[key] += count
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// tally calls fn with the metadata and count of each card in the deck.
+func tally(deck Deck, db *CardDB, fn func(card CardInfo, count uint64)) error {
+	for _, card := range deck.Cards {
+		info, err := db.lookup(card[0])
+		if err != nil {
+			return err
+		}
+		fn(info, card[1])
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMageDeck() Deck {
+	return Deck{
+		Format: FormatWild,
+		Heroes: []uint64{637},
+		Cards: [][2]uint64{
+			{77, 2}, {315, 2}, {395, 2}, {555, 2}, {581, 1}, {662, 2},
+			{749, 1}, {757, 2}, {825, 2}, {1004, 1}, {40408, 1},
+		},
+	}
+}
+
+func TestManaCurve(t *testing.T) {
+	curve, err := ManaCurve(testMageDeck(), testCardDB(t), 7)
+	assert.Nil(t, err)
+	assert.Equal(t, Curve{0, 2, 3, 2, 7, 2, 0, 2}, curve)
+}
+
+func TestManaCurveTop(t *testing.T) {
+	db := testCardDB(t)
+
+	curve, err := ManaCurve(testMageDeck(), db, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, Curve{0, 2, 3, 2, 11}, curve)
+
+	curve, err = ManaCurve(testMageDeck(), db, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, Curve{18}, curve)
+
+	_, err = ManaCurve(testMageDeck(), db, -1)
+	assert.NotNil(t, err)
+}
+
+func TestManaCurveUnknownCard(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{315, 2}, {999999, 1}}}
+	_, err := ManaCurve(deck, testCardDB(t), 7)
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestDustCost(t *testing.T) {
+	db := testCardDB(t)
+
+	// 2 commons, 2 rares, 3 legendaries.
+	dust, err := DustCost(testMageDeck(), db, CraftingCost)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2*40+2*100+3*1600), dust)
+
+	dust, err = DustCost(testMageDeck(), db, GoldenCraftingCost)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2*400+2*800+3*3200), dust)
+}
+
+func TestDustCostCoreSet(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{64678, 2}, {1023, 1}}}
+	dust, err := DustCost(deck, testCardDB(t), CraftingCost)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(400), dust)
+}
+
+func TestDustCostUnknownCard(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{999999, 1}}}
+	_, err := DustCost(deck, testCardDB(t), CraftingCost)
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestDustTableScale(t *testing.T) {
+	scaled := CraftingCost.Scale(3)
+	assert.Equal(t, uint64(4800), scaled[RarityLegendary])
+	assert.Equal(t, uint64(1600), CraftingCost[RarityLegendary])
+}
+
+func TestRarityBreakdown(t *testing.T) {
+	rarities, err := RarityBreakdown(testMageDeck(), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, map[Rarity]uint64{
+		RarityFree:      11,
+		RarityCommon:    2,
+		RarityRare:      2,
+		RarityLegendary: 3,
+	}, rarities)
+}
+
+func TestSetBreakdown(t *testing.T) {
+	sets, err := SetBreakdown(testMageDeck(), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, Breakdown{"LEGACY": 11, "EXPERT1": 6, "GANGS": 1}, sets)
+}
+
+func TestBreakdownUnknownCard(t *testing.T) {
+	deck := Deck{Cards: [][2]uint64{{999999, 1}}}
+
+	_, err := RarityBreakdown(deck, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+
+	_, err = SetBreakdown(deck, testCardDB(t))
+	assert.Equal(t, UnknownCardError{DBFID: 999999}, err)
+}
+
+func TestTypeBreakdown(t *testing.T) {
+	deck := testMageDeck()
+	deck.Cards = append(deck.Cards, [2]uint64{401, 1}, [2]uint64{102983, 1})
+
+	types, err := TypeBreakdown(deck, testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, Breakdown{"MINION": 9, "SPELL": 9, "WEAPON": 1, "LOCATION": 1}, types)
+}
+
+func TestSpellSchoolBreakdown(t *testing.T) {
+	deck := testMageDeck()
+	deck.Cards = append(deck.Cards, [2]uint64{1023, 1})
+
+	schools, err := SpellSchoolBreakdown(deck, testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, Breakdown{"ARCANE": 4, "FIRE": 3, "FROST": 2}, schools)
+}
+
+func TestTribeBreakdown(t *testing.T) {
+	tribes, err := TribeBreakdown(testMageDeck(), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, Breakdown{"DRAGON": 3, "ELEMENTAL": 2}, tribes)
+}
+
+func TestKeywordBreakdown(t *testing.T) {
+	keywords, err := KeywordBreakdown(testMageDeck(), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, Breakdown{
+		"BATTLECRY":     4,
+		"DEATHRATTLE":   1,
+		"DIVINE_SHIELD": 2,
+		"FREEZE":        4,
+		"SPELLPOWER":    3,
+	}, keywords)
+}
+
+func TestKeywordBreakdownInternalMechanics(t *testing.T) {
+	db, err := LoadCardDB(strings.NewReader(`[
+		{"dbfId": 1, "id": "A", "name": "A", "type": "MINION", "mechanics": ["TAUNT", "TRIGGER_VISUAL"]},
+		{"dbfId": 2, "id": "B", "name": "B", "type": "MINION", "mechanics": ["AURA"]}
+	]`))
+	assert.Nil(t, err)
+
+	keywords, err := KeywordBreakdown(Deck{Cards: [][2]uint64{{1, 2}, {2, 1}}}, db)
+	assert.Nil(t, err)
+	assert.Equal(t, Breakdown{"TAUNT": 2}, keywords)
+}
+
+func TestIsKeyword(t *testing.T) {
+	assert.True(t, IsKeyword("TAUNT"))
+	assert.True(t, IsKeyword("DISCOVER"))
+	assert.False(t, IsKeyword("TRIGGER_VISUAL"))
+	assert.False(t, IsKeyword("taunt"))
+}
+
+func TestCurveChart(t *testing.T) {
+	curve := Curve{0, 2, 3, 2, 7, 2, 0, 2}
+
+	assert.Equal(t, ""+
+		"     2  3  2  7  2     2\n"+
+		"             ██\n"+
+		"             ██\n"+
+		"    ▁▁ ▆▆ ▁▁ ██ ▁▁    ▁▁\n"+
+		"    ██ ██ ██ ██ ██    ██\n"+
+		"  0  1  2  3  4  5  6 7+\n", curve.Chart(4))
+
+	assert.Equal(t, ""+
+		"     2  3  2  7  2     2\n"+
+		"             ##\n"+
+		"             ##\n"+
+		"       ##    ##\n"+
+		"    ## ## ## ## ##    ##\n"+
+		"  0  1  2  3  4  5  6 7+\n", curve.ASCIIChart(4))
+
+	// Small counts still show, and an empty curve draws no bars.
+	assert.Equal(t, " 30  1\n ██ ▁▁\n  0 1+\n", Curve{30, 1}.Chart(1))
+	assert.Equal(t, "\n\n  0 1+\n", Curve{0, 0}.Chart(1))
+	assert.Equal(t, "", curve.Chart(0))
+	assert.Equal(t, "", Curve{}.Chart(4))
+
+	// Columns widen to fit large counts and costs.
+	assert.Equal(t, ""+
+		" 1000    2\n"+
+		"   ██\n"+
+		"   ██\n"+
+		"   ██   ▁▁\n"+
+		"    0   1+\n", Curve{1000, 2}.Chart(3))
+}
+
+func TestCurveChartWideCosts(t *testing.T) {
+	db := testCardDB(t)
+	curve, err := ManaCurve(testMageDeck(), db, 100)
+	assert.Nil(t, err)
+
+	chart := curve.ASCIIChart(2)
+	lines := strings.Split(strings.TrimSuffix(chart, "\n"), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasSuffix(lines[3], "  99 100+"))
+	assert.True(t, strings.HasPrefix(lines[3], "    0    1    2"))
+}
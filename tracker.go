@@ -0,0 +1,194 @@
+package deckstrings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TrackerDeck is a deck imported from a companion app's JSON export.
+type TrackerDeck struct {
+	Name string
+	Deck Deck
+}
+
+// trackerDeck mirrors the deck objects exported by companion apps such as
+// Firestone. Apps differ in field names, so common alternatives are accepted.
+type trackerDeck struct {
+	Name        string            `json:"name"`
+	DeckName    string            `json:"deckName"`
+	Hero        string            `json:"hero"`
+	HeroCardID  string            `json:"heroCardId"`
+	HeroDBFID   uint64            `json:"heroDbfId"`
+	PlayerClass string            `json:"playerClass"`
+	Class       string            `json:"class"`
+	Format      json.RawMessage   `json:"format"`
+	Cards       []json.RawMessage `json:"cards"`
+}
+
+// trackerCard is a card entry given as an object rather than a card ID.
+type trackerCard struct {
+	CardID   string  `json:"cardId"`
+	ID       string  `json:"id"`
+	DBFID    uint64  `json:"dbfId"`
+	Count    *uint64 `json:"count"`
+	Quantity *uint64 `json:"quantity"`
+}
+
+// ReadTrackerDecks reads decks from a companion app's JSON export, such as
+// Firestone's, converting them to canonical decks. The input is a single deck
+// object or an array of them, e.g.
+//
+//	{
+//		"name": "Freeze Mage",
+//		"heroCardId": "HERO_08",
+//		"format": "wild",
+//		"cards": [{"cardId": "CS2_029", "count": 2}, "EX1_561"]
+//	}
+//
+// Cards are listed as objects with a cardId (or id) or dbfId and a count (or
+// quantity), defaulting to 1, or as card ID strings, one per copy. Card IDs
+// are resolved to DBF IDs with db. The hero is given by hero or heroCardId as
+// a card ID, by heroDbfId, or else by playerClass or class, which selects the
+// class's default hero (see DefaultHeroes). The format is a name like
+// "standard" or a number, defaulting to FormatWild.
+func ReadTrackerDecks(reader io.Reader, db *CardDB) (decks []TrackerDeck, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "tracker decks read")
+		}
+	}()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []trackerDeck
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &entries)
+	} else {
+		entries = make([]trackerDeck, 1)
+		err = json.Unmarshal(trimmed, &entries[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decks = make([]TrackerDeck, 0, len(entries))
+	for i, entry := range entries {
+		deck, err := entry.deck(db)
+		if err != nil {
+			return nil, errors.Wrapf(err, "deck %d", i)
+		}
+		decks = append(decks, TrackerDeck{Name: firstNonEmpty(entry.Name, entry.DeckName), Deck: deck})
+	}
+
+	return decks, nil
+}
+
+func (d trackerDeck) deck(db *CardDB) (Deck, error) {
+	hero, err := d.hero(db)
+	if err != nil {
+		return Deck{}, err
+	}
+
+	format, err := parseTrackerFormat(d.Format)
+	if err != nil {
+		return Deck{}, err
+	}
+
+	counts := make(map[uint64]uint64, len(d.Cards))
+	for _, raw := range d.Cards {
+		var id string
+		if err := json.Unmarshal(raw, &id); err == nil {
+			dbfID, err := db.lookupID(id)
+			if err != nil {
+				return Deck{}, err
+			}
+			counts[dbfID]++
+			continue
+		}
+
+		var card trackerCard
+		if err := json.Unmarshal(raw, &card); err != nil {
+			return Deck{}, fmt.Errorf("invalid card entry: %s", raw)
+		}
+
+		dbfID := card.DBFID
+		if id := firstNonEmpty(card.CardID, card.ID); id != "" {
+			if dbfID, err = db.lookupID(id); err != nil {
+				return Deck{}, err
+			}
+		} else if dbfID == 0 {
+			return Deck{}, fmt.Errorf("card entry without card ID: %s", raw)
+		}
+
+		count := uint64(1)
+		if card.Count != nil {
+			count = *card.Count
+		} else if card.Quantity != nil {
+			count = *card.Quantity
+		}
+		counts[dbfID] += count
+	}
+
+	return FromCardsMap(format, []uint64{hero}, counts), nil
+}
+
+func (d trackerDeck) hero(db *CardDB) (uint64, error) {
+	if id := firstNonEmpty(d.Hero, d.HeroCardID); id != "" {
+		return db.lookupID(id)
+	}
+
+	if d.HeroDBFID != 0 {
+		return d.HeroDBFID, nil
+	}
+
+	if class := firstNonEmpty(d.PlayerClass, d.Class); class != "" {
+		if hero, ok := DefaultHeroes[strings.ToUpper(class)]; ok {
+			return hero, nil
+		}
+		return 0, fmt.Errorf("unknown class: %q", class)
+	}
+
+	return 0, fmt.Errorf("deck has no hero")
+}
+
+// parseTrackerFormat parses a format given as a name, e.g. "standard" or
+// "FT_STANDARD", or as a number.
+func parseTrackerFormat(raw json.RawMessage) (Format, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return FormatWild, nil
+	}
+
+	var number uint64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return Format(number), nil
+	}
+
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return 0, fmt.Errorf("invalid format: %s", raw)
+	}
+
+	name = strings.TrimPrefix(strings.ToLower(name), "ft_")
+	if format, ok := formatByName(name); ok {
+		return format, nil
+	}
+
+	return 0, fmt.Errorf("unknown format: %q", name)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package deckstrings_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTrackerDecks(t *testing.T) {
+	input := `{
+		"name": "Freeze Mage",
+		"heroCardId": "HERO_08",
+		"format": "wild",
+		"cards": [{"cardId": "CS2_029", "count": 2}, "EX1_561", {"dbfId": 757, "quantity": 2}, {"id": "CS2_022"}]
+	}`
+
+	decks, err := ReadTrackerDecks(strings.NewReader(input), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, []TrackerDeck{{
+		Name: "Freeze Mage",
+		Deck: Deck{
+			Format: FormatWild,
+			Heroes: []uint64{637},
+			Cards:  [][2]uint64{{77, 1}, {315, 2}, {581, 1}, {757, 2}},
+		},
+	}}, decks)
+}
+
+func TestReadTrackerDecksArray(t *testing.T) {
+	input := `[
+		{"deckName": "A", "playerClass": "warrior", "format": "FT_STANDARD", "cards": ["CS2_106", "CS2_106"]},
+		{"deckName": "B", "heroDbfId": 637, "format": 3, "cards": []}
+	]`
+
+	decks, err := ReadTrackerDecks(strings.NewReader(input), testCardDB(t))
+	assert.Nil(t, err)
+	assert.Equal(t, []TrackerDeck{
+		{Name: "A", Deck: Deck{Format: FormatStandard, Heroes: []uint64{7}, Cards: [][2]uint64{{401, 2}}}},
+		{Name: "B", Deck: Deck{Format: FormatClassic, Heroes: []uint64{637}, Cards: [][2]uint64{}}},
+	}, decks)
+}
+
+func TestReadTrackerDecksInvalid(t *testing.T) {
+	db := testCardDB(t)
+
+	invalid := map[string]string{
+		`{`:                 "tracker decks read: unexpected end of JSON input",
+		`{"cards": []}`:     "tracker decks read: deck 0: deck has no hero",
+		`{"class": "bard"}`: `tracker decks read: deck 0: unknown class: "bard"`,
+		`{"hero": "HERO_08", "format": "battlegrounds"}`: `tracker decks read: deck 0: unknown format: "battlegrounds"`,
+		`{"hero": "HERO_08", "cards": ["NOPE_001"]}`:     `tracker decks read: deck 0: unknown card ID: "NOPE_001"`,
+		`{"hero": "HERO_08", "cards": [{"count": 2}]}`:   `tracker decks read: deck 0: card entry without card ID: {"count": 2}`,
+		`{"hero": "HERO_08", "cards": [true]}`:           "tracker decks read: deck 0: invalid card entry: true",
+	}
+
+	for input, message := range invalid {
+		_, err := ReadTrackerDecks(strings.NewReader(input), db)
+		assert.EqualError(t, err, message, input)
+	}
+}
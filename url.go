@@ -0,0 +1,164 @@
+package deckstrings
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// deckQueryParams are query parameters that carry a deck code in deck
+// builder and sharing links, e.g. Blizzard's deck builder at
+// https://hearthstone.blizzard.com/deckbuilder?deckcode=...
+var deckQueryParams = []string{"deckcode", "deckstring", "code", "deck"}
+
+// deckPathPrefixes maps site hosts to the path prefix that precedes a deck
+// code in their deck URLs, e.g. https://www.d0nkey.top/deck/AAECA...
+var deckPathPrefixes = map[string]string{
+	"d0nkey.top": "/deck/",
+	"hsguru.com": "/deck/",
+}
+
+// idDeckHosts are sites whose deck pages identify decks by a site-specific ID
+// or slug, e.g. https://hsreplay.net/decks/mEd8MVbVOaeqSbhfdRIxUd/.
+var idDeckHosts = map[string]bool{
+	"hsreplay.net":            true,
+	"hearthstonetopdecks.com": true,
+}
+
+// DeckstringFromURL extracts the deck code from a link to a deck, so bots can
+// accept a pasted link instead of the raw code. It understands deck pages on
+// d0nkey.top and hsguru.com, Blizzard's deck builder, and any link carrying
+// the code in a deckcode, deckstring, code, or deck query parameter. The code
+// is decoded to check that it is valid; options are passed to Decode.
+//
+// Links that identify decks by a site-specific ID or slug rather than a deck
+// code, such as HSReplay.net and Hearthstone Top Decks deck pages, can't be
+// resolved without querying the site and return an error.
+func DeckstringFromURL(rawURL string, opts ...DecodeOption) (string, error) {
+	deckstring, _, err := parseDeckURL(rawURL, opts)
+	if err != nil {
+		return "", errors.Wrap(err, "deck URL")
+	}
+	return deckstring, nil
+}
+
+// DecodeURL decodes the deck linked to by a URL. See DeckstringFromURL for the
+// supported links.
+func DecodeURL(rawURL string, opts ...DecodeOption) (Deck, error) {
+	_, deck, err := parseDeckURL(rawURL, opts)
+	if err != nil {
+		return Deck{}, errors.Wrap(err, "deck URL")
+	}
+	return deck, nil
+}
+
+func parseDeckURL(rawURL string, opts []DecodeOption) (deckstring string, deck Deck, err error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", Deck{}, err
+	}
+
+	if u.Host == "" {
+		return "", Deck{}, fmt.Errorf("not an absolute URL: %q", rawURL)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+
+	var candidates []string
+	if prefix, ok := deckPathPrefixes[host]; ok && strings.HasPrefix(u.Path, prefix) {
+		candidates = append(candidates, strings.TrimSuffix(u.Path[len(prefix):], "/"))
+	}
+
+	query := u.Query()
+	for _, param := range deckQueryParams {
+		if value := query.Get(param); value != "" {
+			// A '+' left unescaped in a query decodes as a space.
+			candidates = append(candidates, strings.ReplaceAll(value, " ", "+"))
+		}
+	}
+
+	if len(candidates) == 0 {
+		if idDeckHosts[host] {
+			return "", Deck{}, fmt.Errorf("%s links identify decks by ID rather than deck code", host)
+		}
+		return "", Deck{}, fmt.Errorf("no deck code in URL: %q", rawURL)
+	}
+
+	for _, candidate := range candidates {
+		if deck, err = Decode(candidate, opts...); err == nil {
+			return candidate, deck, nil
+		}
+	}
+
+	return "", Deck{}, err
+}
+
+// Site is a website with shareable deck pages. See DeckURL.
+type Site int
+
+const (
+	// SiteBlizzard is Blizzard's official deck builder.
+	SiteBlizzard Site = iota + 1
+
+	// SiteD0nkey is d0nkey.top.
+	SiteD0nkey
+
+	// SiteHSGuru is hsguru.com.
+	SiteHSGuru
+)
+
+var siteNames = map[Site]string{
+	SiteBlizzard: "hearthstone.blizzard.com",
+	SiteD0nkey:   "d0nkey.top",
+	SiteHSGuru:   "hsguru.com",
+}
+
+func (s Site) String() string {
+	if name, ok := siteNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Site(%d)", int(s))
+}
+
+// siteURLs build the URL of a deck page on each site from an unescaped deck
+// code.
+var siteURLs = map[Site]func(deckstring string) string{
+	SiteBlizzard: func(deckstring string) string {
+		return "https://hearthstone.blizzard.com/deckbuilder?deckcode=" + url.QueryEscape(deckstring)
+	},
+	SiteD0nkey: func(deckstring string) string {
+		return "https://www.d0nkey.top/deck/" + url.PathEscape(deckstring)
+	},
+	SiteHSGuru: func(deckstring string) string {
+		return "https://www.hsguru.com/deck/" + url.PathEscape(deckstring)
+	},
+}
+
+// DeckURL returns a link to the deck page for a deckstring on site, e.g.
+// "https://www.d0nkey.top/deck/AAECAR8G...%2FgIDI0B...+DAA=". The deckstring is
+// re-encoded in canonical form so equal decks share a URL. The URL can be
+// parsed back with DeckstringFromURL.
+func DeckURL(site Site, deckstring string) (string, error) {
+	deck, err := Decode(deckstring)
+	if err != nil {
+		return "", errors.Wrap(err, "deck URL")
+	}
+	return EncodeURL(site, deck)
+}
+
+// EncodeURL is like DeckURL for a deck. Options are passed to Encode.
+func EncodeURL(site Site, deck Deck, opts ...EncodeOption) (string, error) {
+	build, ok := siteURLs[site]
+	if !ok {
+		return "", fmt.Errorf("deck URL: unsupported site: %s", site)
+	}
+
+	deckstring, err := Encode(deck, opts...)
+	if err != nil {
+		return "", errors.Wrap(err, "deck URL")
+	}
+
+	return build(deckstring), nil
+}
@@ -0,0 +1,89 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeckstringFromURL(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	urls := []string{
+		"https://www.d0nkey.top/deck/AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D",
+		"https://d0nkey.top/deck/AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=",
+		"https://www.hsguru.com/deck/AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D/",
+		"https://hearthstone.blizzard.com/en-us/deckbuilder?deckcode=AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D",
+		"https://example.com/?deckstring=AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=",
+		"  https://example.com/view?code=AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D  ",
+	}
+
+	for _, u := range urls {
+		parsed, err := DeckstringFromURL(u)
+		assert.Nil(t, err, u)
+		assert.Equal(t, deckstring, parsed, u)
+	}
+
+	deck, err := DecodeURL(urls[0])
+	assert.Nil(t, err)
+	assert.Equal(t, MustDecode(deckstring), deck)
+}
+
+func TestDeckstringFromURLInvalid(t *testing.T) {
+	_, err := DeckstringFromURL("https://hsreplay.net/decks/mEd8MVbVOaeqSbhfdRIxUd/")
+	assert.EqualError(t, err, "deck URL: hsreplay.net links identify decks by ID rather than deck code")
+
+	_, err = DeckstringFromURL("https://www.hearthstonetopdecks.com/decks/big-spell-mage/")
+	assert.EqualError(t, err, "deck URL: hearthstonetopdecks.com links identify decks by ID rather than deck code")
+
+	_, err = DeckstringFromURL("https://example.com/decks/1234")
+	assert.EqualError(t, err, `deck URL: no deck code in URL: "https://example.com/decks/1234"`)
+
+	_, err = DeckstringFromURL("AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=")
+	assert.NotNil(t, err)
+
+	_, err = DeckstringFromURL("https://d0nkey.top/deck/nope")
+	assert.NotNil(t, err)
+
+	_, err = DeckstringFromURL("https://d0nkey.top/deck/AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D", RequireFormat(FormatWild))
+	assert.NotNil(t, err)
+}
+
+func TestDeckURL(t *testing.T) {
+	deckstring := "AAECAR8GxwPJBLsFmQfZB/gIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA="
+
+	expected := map[Site]string{
+		SiteBlizzard: "https://hearthstone.blizzard.com/deckbuilder?deckcode=AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr%2BDAA%3D",
+		SiteD0nkey:   "https://www.d0nkey.top/deck/AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=",
+		SiteHSGuru:   "https://www.hsguru.com/deck/AAECAR8GxwPJBLsFmQfZB%2FgIDI0B2AGoArUDhwSSBe0G6wfbCe0JgQr+DAA=",
+	}
+
+	for site, expectedURL := range expected {
+		u, err := DeckURL(site, deckstring)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedURL, u)
+
+		parsed, err := DeckstringFromURL(u)
+		assert.Nil(t, err)
+		assert.Equal(t, deckstring, parsed)
+	}
+}
+
+func TestDeckURLCanonical(t *testing.T) {
+	// Unsorted heroes.
+	u, err := DeckURL(SiteD0nkey, "AAEAAgIBAAAA")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://www.d0nkey.top/deck/AAEAAgECAAAA", u)
+}
+
+func TestDeckURLInvalid(t *testing.T) {
+	_, err := DeckURL(SiteD0nkey, "nope")
+	assert.NotNil(t, err)
+
+	_, err = EncodeURL(Site(9), Deck{})
+	assert.EqualError(t, err, "deck URL: unsupported site: Site(9)")
+
+	_, err = EncodeURL(SiteBlizzard, Deck{Cards: [][2]uint64{{1, 0}}})
+	assert.EqualError(t, err, "deck URL: deckstring encode: invalid card count for DBF ID 1")
+}
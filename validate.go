@@ -0,0 +1,171 @@
+package deckstrings
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ViolationKind identifies a deck building rule broken by a deck.
+type ViolationKind int
+
+const (
+	// The deck does not have the required number of cards.
+	ViolationDeckSize ViolationKind = iota + 1
+
+	// The deck does not have the required number of heroes.
+	ViolationHeroCount
+
+	// A card has more copies than allowed.
+	ViolationCopies
+
+	// A card or hero is not in the card database.
+	ViolationUnknownCard
+
+	// A card is not collectible.
+	ViolationNotCollectible
+
+	// A card belongs to a class other than the hero's.
+	ViolationClass
+)
+
+var violationKindNames = map[ViolationKind]string{
+	ViolationDeckSize:       "deck size",
+	ViolationHeroCount:      "hero count",
+	ViolationCopies:         "copies",
+	ViolationUnknownCard:    "unknown card",
+	ViolationNotCollectible: "not collectible",
+	ViolationClass:          "class",
+}
+
+func (k ViolationKind) String() string {
+	if name, ok := violationKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("ViolationKind(%d)", int(k))
+}
+
+// Violation describes a deck building rule broken by a deck. DBFID is the card
+// or hero involved, or 0 for rules about the deck as a whole.
+type Violation struct {
+	Kind    ViolationKind
+	DBFID   uint64
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Kind, v.Message)
+}
+
+// Rules is a set of deck building rules. Zero-valued limits are not checked.
+// See ConstructedRules and ArenaRules for presets.
+type Rules struct {
+	// DeckSize is the required total number of cards.
+	DeckSize uint64
+
+	// Heroes is the required number of heroes.
+	Heroes int
+
+	// MaxCopies is the maximum number of copies of any card.
+	MaxCopies uint64
+
+	// MaxLegendaryCopies is the maximum number of copies of a legendary card.
+	// Requires DB.
+	MaxLegendaryCopies uint64
+
+	// DB, if set, enables checks that need card metadata: every card and hero
+	// must be known, cards must be collectible, cards must be neutral or of
+	// the hero's class, and MaxLegendaryCopies.
+	DB *CardDB
+}
+
+// ConstructedRules returns the rules for constructed decks: 30 cards, one
+// hero, and at most two copies of a card. Set DB on the result to also check
+// legendary copies, collectibility, and class.
+func ConstructedRules() Rules {
+	return Rules{
+		DeckSize:           30,
+		Heroes:             1,
+		MaxCopies:          2,
+		MaxLegendaryCopies: 1,
+	}
+}
+
+// ArenaRules returns the rules for arena decks: 30 cards and one hero, with no
+// limit on copies.
+func ArenaRules() Rules {
+	return Rules{
+		DeckSize: 30,
+		Heroes:   1,
+	}
+}
+
+// Validate checks the deck against the rules and returns every violation
+// found, or nil if the deck follows the rules. Duplicate entries for a card
+// are summed before checking copy limits.
+func (r Rules) Validate(deck Deck) []Violation {
+	var violations []Violation
+	add := func(kind ViolationKind, dbfID uint64, format string, args ...interface{}) {
+		violations = append(violations, Violation{Kind: kind, DBFID: dbfID, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if r.Heroes > 0 && len(deck.Heroes) != r.Heroes {
+		add(ViolationHeroCount, 0, "deck has %d heroes, expected %d", len(deck.Heroes), r.Heroes)
+	}
+
+	if total := deck.TotalCards(); r.DeckSize > 0 && total != r.DeckSize {
+		add(ViolationDeckSize, 0, "deck has %d cards, expected %d", total, r.DeckSize)
+	}
+
+	class := ""
+	if r.DB != nil {
+		for _, hero := range deck.Heroes {
+			if info, ok := r.DB.Card(hero); !ok {
+				add(ViolationUnknownCard, hero, "unknown hero: DBF ID %d", hero)
+			} else if class == "" {
+				class = info.Class
+			}
+		}
+	}
+
+	counts := deck.CardsMap()
+	for _, dbfID := range sortedKeys(counts) {
+		count := counts[dbfID]
+		if r.MaxCopies > 0 && count > r.MaxCopies {
+			add(ViolationCopies, dbfID, "DBF ID %d has %d copies, at most %d allowed", dbfID, count, r.MaxCopies)
+		}
+
+		if r.DB == nil {
+			continue
+		}
+
+		info, ok := r.DB.Card(dbfID)
+		if !ok {
+			add(ViolationUnknownCard, dbfID, "unknown card: DBF ID %d", dbfID)
+			continue
+		}
+		if !info.Collectible {
+			add(ViolationNotCollectible, dbfID, "%s (%d) is not collectible", info.Name, dbfID)
+		}
+		if info.Rarity == RarityLegendary && r.MaxLegendaryCopies > 0 && count > r.MaxLegendaryCopies {
+			add(ViolationCopies, dbfID, "legendary %s (%d) has %d copies, at most %d allowed", info.Name, dbfID, count, r.MaxLegendaryCopies)
+		}
+		if class != "" && !info.playableBy(class) {
+			add(ViolationClass, dbfID, "%s (%d) cannot be played by %s", info.Name, dbfID, class)
+		}
+	}
+
+	return violations
+}
+
+// DecodeValid decodes a deckstring and validates the deck against the rules.
+// An error is returned only if decoding fails; rule violations are returned
+// separately so callers can report all of them.
+func DecodeValid(deckstring string, rules Rules, opts ...DecodeOption) (deck Deck, violations []Violation, err error) {
+	deck, _, err = decode(newPayloadReader(deckstring), newDecodeOptions(opts))
+	if err != nil {
+		return Deck{}, nil, errors.Wrap(err, "deckstring decode")
+	}
+
+	return deck, rules.Validate(deck), nil
+}
@@ -0,0 +1,70 @@
+package deckstrings_test
+
+import (
+	"testing"
+
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeValid(t *testing.T) {
+	deckstring := "AAECAZICCPIF+Az5DK6rAuC7ApS9AsnHApnTAgtAX/4BxAbkCLS7Asu8As+8At2+AqDNAofOAgA="
+	deck, violations, err := DecodeValid(deckstring, ConstructedRules())
+	assert.Nil(t, err)
+	assert.Nil(t, violations)
+	assert.Equal(t, uint64(30), deck.TotalCards())
+}
+
+func TestDecodeValidInvalid(t *testing.T) {
+	_, violations, err := DecodeValid("AAIAAAAAAA==", ConstructedRules())
+	assert.NotNil(t, err)
+	assert.Nil(t, violations)
+}
+
+func TestValidateStructural(t *testing.T) {
+	deck := Deck{
+		Heroes: []uint64{7, 31},
+		Cards:  [][2]uint64{{141, 2}, {455, 3}, {141, 1}},
+	}
+
+	violations := ConstructedRules().Validate(deck)
+	assert.Equal(t, []Violation{
+		{Kind: ViolationHeroCount, Message: "deck has 2 heroes, expected 1"},
+		{Kind: ViolationDeckSize, Message: "deck has 6 cards, expected 30"},
+		{Kind: ViolationCopies, DBFID: 141, Message: "DBF ID 141 has 3 copies, at most 2 allowed"},
+		{Kind: ViolationCopies, DBFID: 455, Message: "DBF ID 455 has 3 copies, at most 2 allowed"},
+	}, violations)
+
+	assert.Equal(t, "hero count: deck has 2 heroes, expected 1", violations[0].String())
+}
+
+func TestValidateCardDB(t *testing.T) {
+	rules := ConstructedRules()
+	rules.DeckSize = 0
+	rules.DB = testCardDB(t)
+
+	deck := Deck{
+		Heroes: []uint64{637},
+		Cards:  [][2]uint64{{1, 1}, {315, 2}, {401, 1}, {581, 2}, {40408, 1}, {99999, 1}},
+	}
+
+	var kinds []ViolationKind
+	var ids []uint64
+	for _, violation := range rules.Validate(deck) {
+		kinds = append(kinds, violation.Kind)
+		ids = append(ids, violation.DBFID)
+	}
+
+	assert.Equal(t, []ViolationKind{
+		ViolationNotCollectible,
+		ViolationClass,
+		ViolationCopies,
+		ViolationUnknownCard,
+	}, kinds)
+	assert.Equal(t, []uint64{1, 401, 581, 99999}, ids)
+}
+
+func TestValidateArena(t *testing.T) {
+	deck := Deck{Heroes: []uint64{637}, Cards: [][2]uint64{{315, 30}}}
+	assert.Nil(t, ArenaRules().Validate(deck))
+}
@@ -7,21 +7,23 @@ import (
 
 type varintReader struct {
 	reader io.ByteReader
+	offset int
+}
+
+func (r *varintReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.offset++
+	}
+	return b, err
 }
 
 func (r *varintReader) Read() (uint64, error) {
-	return binary.ReadUvarint(r.reader)
+	return binary.ReadUvarint(r)
 }
 
-func (r *varintReader) ReadMany(values []uint64) error {
-	for i := 0; i < len(values); i++ {
-		if value, err := binary.ReadUvarint(r.reader); err != nil {
-			return err
-		} else {
-			values[i] = value
-		}
-	}
-	return nil
+func (r *varintReader) ReadSigned() (int64, error) {
+	return binary.ReadVarint(r)
 }
 
 type varintWriter struct {
@@ -35,6 +37,13 @@ func (w *varintWriter) Write(value uint64) error {
 	return err
 }
 
+func (w *varintWriter) WriteSigned(value int64) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(buf, value)
+	_, err := w.writer.Write(buf[:n])
+	return err
+}
+
 func (w *varintWriter) WriteMany(values []uint64) error {
 	buf := make([]byte, len(values)*binary.MaxVarintLen64)
 	total := 0
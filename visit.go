@@ -0,0 +1,97 @@
+package deckstrings
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Section identifies the kind of element passed to a DecodeFunc callback.
+type Section int
+
+const (
+	// SectionFormat is the deck's format. Values: format.
+	SectionFormat Section = iota + 1
+
+	// SectionHero is a hero. Values: DBF ID.
+	SectionHero
+
+	// SectionCard is a card. Values: DBF ID, count.
+	SectionCard
+)
+
+var sectionNames = map[Section]string{
+	SectionFormat: "format",
+	SectionHero:   "hero",
+	SectionCard:   "card",
+}
+
+func (s Section) String() string {
+	if name, ok := sectionNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Section(%d)", int(s))
+}
+
+// DecodeFunc decodes a deckstring, calling fn for each element as it is
+// decoded instead of building a Deck. This lets custom consumers, such as
+// database inserts, skip intermediate structures entirely.
+//
+// Elements are passed in wire order: the format, each hero, then each card
+// with its count. Heroes and cards are not sorted and duplicate cards are not
+// merged. If fn returns an error, decoding stops and that error is returned
+// unchanged. Deckstrings and options are handled as in ParseHeader; the
+// elements of a deckstring decoded by a registered codec are passed in the
+// order the codec returns them.
+func DecodeFunc(deckstring string, fn func(section Section, values ...uint64) error, opts ...DecodeOption) error {
+	var fnErr error
+	call := func(section Section, values ...uint64) error {
+		fnErr = fn(section, values...)
+		return fnErr
+	}
+
+	var dbfID uint64
+	deck, err := parseDeckstring(deckstring, newDecodeOptions(opts), func(field Field) error {
+		switch {
+		case field.Kind == FieldFormat:
+			return call(SectionFormat, field.Value)
+		case field.Kind == FieldHero:
+			return call(SectionHero, field.Value)
+		case field.Kind == FieldCard:
+			dbfID = field.Value
+			if field.Group < 3 {
+				return call(SectionCard, dbfID, uint64(field.Group))
+			}
+		case field.Kind == FieldCount:
+			return call(SectionCard, dbfID, field.Value)
+		}
+		return nil
+	})
+
+	if deck != nil {
+		err = visitDeck(*deck, call)
+	}
+
+	if err != nil && err == fnErr {
+		return err
+	}
+	return errors.Wrap(err, "deckstring decode")
+}
+
+// visitDeck passes the elements of deck to fn in the order DecodeFunc uses.
+func visitDeck(deck Deck, fn func(section Section, values ...uint64) error) error {
+	if err := fn(SectionFormat, uint64(deck.Format)); err != nil {
+		return err
+	}
+	for _, hero := range deck.Heroes {
+		if err := fn(SectionHero, hero); err != nil {
+			return err
+		}
+	}
+	for _, card := range deck.Cards {
+		if err := fn(SectionCard, card[0], card[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package deckstrings_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/schmich/deckstrings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFunc(t *testing.T) {
+	var visited []string
+	err := DecodeFunc("AAEBAQcBBQEGAQcD", func(section Section, values ...uint64) error {
+		visited = append(visited, fmt.Sprint(section, values))
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"format [1]", "hero [7]", "card [5 1]", "card [6 2]", "card [7 3]"}, visited)
+}
+
+func TestDecodeFuncDecodeOptions(t *testing.T) {
+	deck := MustDecode("AAEBAQcBBQEGAQcD")
+	for _, deckstring := range []string{MustEncode(deck, EncodeVersion(99)), MustEncode(deck, EncodeReserved(5))} {
+		var visited []string
+		err := DecodeFunc(deckstring, func(section Section, values ...uint64) error {
+			visited = append(visited, fmt.Sprint(section, values))
+			return nil
+		}, AllowReserved())
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"format [1]", "hero [7]", "card [5 1]", "card [6 2]", "card [7 3]"}, visited)
+	}
+}
+
+func TestDecodeFuncStop(t *testing.T) {
+	stop := errors.New("stop")
+
+	calls := 0
+	err := DecodeFunc("AAEBAQcBBQEGAQcD", func(section Section, values ...uint64) error {
+		calls++
+		if section == SectionHero {
+			return stop
+		}
+		return nil
+	})
+
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestDecodeFuncInvalid(t *testing.T) {
+	err := DecodeFunc("AAEBAQcBBQ==", func(Section, ...uint64) error { return nil })
+	assert.NotNil(t, err)
+}
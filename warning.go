@@ -0,0 +1,69 @@
+package deckstrings
+
+import "fmt"
+
+// WarningKind identifies a property of a deckstring that is valid but suspicious.
+type WarningKind int
+
+const (
+	// Heroes are not listed in ascending DBF ID order.
+	WarningUnsortedHeroes WarningKind = iota + 1
+
+	// Cards within a group are not listed in ascending DBF ID order.
+	WarningUnsortedCards
+
+	// A card has a count greater than 2.
+	WarningHighCount
+
+	// The deck has no heroes.
+	WarningNoHeroes
+
+	// The deck's format is not one known to this package.
+	WarningUnknownFormat
+
+	// A card is listed more than once.
+	WarningDuplicateCard
+
+	// A value is encoded with more bytes than necessary.
+	WarningOverlongVarint
+
+	// Data follows the card groups that this package does not decode, such
+	// as sideboards or groups added by a newer revision of the format.
+	WarningTrailingData
+
+	// The deckstring's version is newer than Version and was decoded on a
+	// best-effort basis. See DecodeNewerVersions.
+	WarningNewerVersion
+)
+
+var warningKindNames = map[WarningKind]string{
+	WarningUnsortedHeroes: "unsorted heroes",
+	WarningUnsortedCards:  "unsorted cards",
+	WarningHighCount:      "high count",
+	WarningNoHeroes:       "no heroes",
+	WarningUnknownFormat:  "unknown format",
+	WarningDuplicateCard:  "duplicate card",
+	WarningOverlongVarint: "overlong varint",
+	WarningTrailingData:   "trailing data",
+	WarningNewerVersion:   "newer version",
+}
+
+func (k WarningKind) String() string {
+	if name, ok := warningKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("WarningKind(%d)", int(k))
+}
+
+// Warning describes a property of a deckstring that does not prevent decoding
+// but that Decode silently normalizes or accepts. Offset is the byte offset in
+// the base64-decoded payload of the field that caused the warning.
+type Warning struct {
+	Kind    WarningKind
+	Offset  int
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s at offset %d: %s", w.Kind, w.Offset, w.Message)
+}